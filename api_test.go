@@ -0,0 +1,102 @@
+package main_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	myvalidator "github.com/opencodeco/myvalidator"
+)
+
+func TestFieldTestElementsFor(t *testing.T) {
+	field := myvalidator.FieldInfo{
+		Name:        "Code",
+		Type:        "string",
+		Validations: []string{"gte=2", "regexp=^[A-Z]+$", "uuid"},
+	}
+
+	tests, err := myvalidator.FieldTestElementsFor(field)
+	if err != nil {
+		t.Fatalf("FieldTestElementsFor() error = %v", err)
+	}
+	if len(tests) != 3 {
+		t.Fatalf("FieldTestElementsFor() = %d tests, want 3", len(tests))
+	}
+
+	loperand, operator, roperand := tests[0].Operands()
+	if loperand != "len(obj.Code)" || operator != "<" || roperand != "2" {
+		t.Errorf("Operands() = %q %q %q, want len(obj.Code) < 2", loperand, operator, roperand)
+	}
+	if got, want := tests[0].ErrorMessage(), "length Code must be >= 2"; got != want {
+		t.Errorf("ErrorMessage() = %q, want %q", got, want)
+	}
+
+	if got, want := tests[1].Condition(), "!fieldPattern0.MatchString(obj.Code)"; got != want {
+		t.Errorf("Condition() = %q, want %q", got, want)
+	}
+	if name, pattern := tests[1].Regexp(); name != "fieldPattern0" || pattern != "^[A-Z]+$" {
+		t.Errorf("Regexp() = %q, %q, want fieldPattern0, ^[A-Z]+$", name, pattern)
+	}
+	if got, want := tests[1].Imports(), []string{"regexp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Imports() = %v, want %v", got, want)
+	}
+
+	if name, _ := tests[2].Regexp(); name != "uuidRegexp" {
+		t.Errorf("Regexp() name = %q, want uuidRegexp", name)
+	}
+	if got := tests[2].Helper(); got != "" {
+		t.Errorf("Helper() = %q, want no helper", got)
+	}
+
+	tests, err = myvalidator.FieldTestElementsFor(myvalidator.FieldInfo{Name: "Card", Type: "string", Validations: []string{"credit_card"}})
+	if err != nil {
+		t.Fatalf("FieldTestElementsFor() error = %v", err)
+	}
+	if got := tests[0].Helper(); !strings.Contains(got, "func luhnValid(") {
+		t.Errorf("Helper() = %q, want the luhnValid function", got)
+	}
+}
+
+func TestRegisterValidation(t *testing.T) {
+	myvalidator.RegisterValidation("prefixed", func(fieldName, arg, fieldType string) (myvalidator.FieldTestElements, error) {
+		if fieldType != "string" {
+			return myvalidator.FieldTestElements{}, fmt.Errorf("prefixed on type %s is unsupported", fieldType)
+		}
+
+		return myvalidator.NewFieldTestElements(
+			"!strings.HasPrefix({{.Name}}, {{.QuotedTarget}})",
+			"{{.Name}} must be prefixed by {{.Target}}",
+			"strings",
+		), nil
+	})
+
+	fv := myvalidator.StructInfo{
+		Name: "Car",
+		FieldsInfo: []myvalidator.FieldInfo{
+			{Name: "Plate", Type: "string", Validations: []string{"prefixed=BR"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+	}
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`"strings"`,
+		`if !strings.HasPrefix(obj.Plate, "BR") {`,
+		`fmt.Errorf("%w: Plate must be prefixed by BR", ErrValidation)`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FileValidator.Generate() = %v, want %v", got, want)
+		}
+	}
+
+	fv.FieldsInfo[0].Type = "int"
+	if _, err := fv.GenerateValidator(); err == nil {
+		t.Errorf("FileValidator.Generate() expected an error for an unsupported type")
+	}
+}
@@ -3,16 +3,40 @@ package main
 func generateCode(structs []StructInfo) error {
 	// TODO: validate tags ok?
 
+	nestedStructs := map[string]bool{}
+	for _, structInfo := range structs {
+		if structInfo.HasValidateTag {
+			nestedStructs[structInfo.Name] = true
+		}
+	}
+
+	var validated []StructInfo
 	for _, structInfo := range structs {
 		if !structInfo.HasValidateTag {
 			continue
 		}
 
-		if err := structInfo.GenerateFilePackageDefinition(); err != nil {
-			return err
-		}
+		structInfo.NestedStructs = nestedStructs
+		validated = append(validated, structInfo)
+	}
+
+	if len(validated) == 0 {
+		return nil
+	}
+
+	// The validators of a package declare its regexps and helpers once.
+	p := PackageInfo{PackageName: validated[0].PackageName, Structs: validated}
+	validators, err := p.GenerateValidators()
+	if err != nil {
+		return err
+	}
+
+	if err := validated[0].GenerateFilePackageDefinition(); err != nil {
+		return err
+	}
 
-		if err := structInfo.GenerateFileValidator(); err != nil {
+	for i, structInfo := range validated {
+		if err := structInfo.writeValidatorFile(validators[i]); err != nil {
 			return err
 		}
 	}
@@ -6,28 +6,55 @@ import (
 	"path/filepath"
 )
 
-func findFiles(path string) error {
-	if err := filepath.WalkDir(path, walk); err != nil {
+func findFiles(path, tagName string) error {
+	// The structs are generated per directory, as the files of a package
+	// share its declarations.
+	var dirs []string
+	packages := map[string][]StructInfo{}
+	walkFunc := func(path string, d os.DirEntry, err error) error {
+		structs, err := walk(path, d, err, tagName)
+		if err != nil {
+			return err
+		}
+
+		if len(structs) > 0 {
+			dir := filepath.Dir(path)
+			if _, ok := packages[dir]; !ok {
+				dirs = append(dirs, dir)
+			}
+			packages[dir] = append(packages[dir], structs...)
+		}
+
+		return nil
+	}
+
+	if err := filepath.WalkDir(path, walkFunc); err != nil {
 		return err
 	}
 
+	for _, dir := range dirs {
+		if err := generateCode(packages[dir]); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	return nil
 }
 
-func walk(path string, d os.DirEntry, err error) error {
+func walk(path string, d os.DirEntry, err error, tagName string) ([]StructInfo, error) {
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if d.IsDir() {
-		return nil
+		return nil, nil
 	}
 
 	if filepath.Ext(path) != ".go" {
-		return nil
+		return nil, nil
 	}
 
-	structs, err := parseFile(path)
+	structs, err := parseFile(path, tagName)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -36,9 +63,5 @@ func walk(path string, d os.DirEntry, err error) error {
 		structInfo.PrintInfo()
 	}
 
-	if err := generateCode(structs); err != nil {
-		log.Fatal(err)
-	}
-
-	return nil
+	return structs, nil
 }
@@ -1,17 +1,19 @@
 package main
 
 import (
+	"flag"
 	"log"
-	"os"
 )
 
 func main() {
-	argsWithoutCmd := os.Args[1:]
-	if len(argsWithoutCmd) == 0 {
-		log.Fatal("Invalid parameters:\n\tvalidatorgen <path>\n")
+	tagName := flag.String("tag", defaultTagName, "struct tag keys holding the validations, separated by commas")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		log.Fatal("Invalid parameters:\n\tvalidatorgen [-tag name] <path>\n")
 	}
 
-	if err := findFiles(argsWithoutCmd[0]); err != nil {
+	if err := findFiles(flag.Arg(0), *tagName); err != nil {
 		log.Fatal(err)
 	}
 }
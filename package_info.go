@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+var registryTpl = `package {{.PackageName}}
+
+// Validators maps the struct names to their validators.
+var Validators = map[string]func(interface{}) []error{
+{{range .Entries}}	"{{.Name}}": func(obj interface{}) []error { return {{.Call}} },
+{{end}}}
+`
+
+// PackageInfo holds the structs of a package, for code generated across
+// all of them.
+type PackageInfo struct {
+	PackageName     string
+	Structs         []StructInfo
+	DeclareSentinel bool // Declare each sentinel error once in the validators.
+}
+
+// GenerateValidators generates the validators of the structs with
+// validations. With DeclareSentinel, each sentinel error is declared only by
+// the first validator wrapping it. The ValidationError type is declared only
+// by the first validator with structured errors, and each regexp or helper
+// only by the first validator using it.
+func (p *PackageInfo) GenerateValidators() ([]string, error) {
+	var validators []string
+	declared := map[string]bool{}
+	errorTypeDeclared := false
+	regexps := map[string]string{}
+	helpers := map[string]bool{}
+	for _, structInfo := range p.Structs {
+		if !structInfo.HasValidateTag {
+			continue
+		}
+
+		structInfo.DeclareSentinel = p.DeclareSentinel && !declared[structInfo.Sentinel()]
+		declared[structInfo.Sentinel()] = true
+
+		structInfo.ErrorTypeDeclared = structInfo.ErrorTypeDeclared || errorTypeDeclared
+		errorTypeDeclared = errorTypeDeclared || structInfo.DeclaresErrorType()
+
+		structInfo.DeclaredRegexps = regexps
+		structInfo.DeclaredHelpers = helpers
+
+		code := new(strings.Builder)
+		validator, err := structInfo.writeValidator(code)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, regexp := range validator.Regexps {
+			regexps[regexp.Pattern] = regexp.Name
+		}
+		for _, helper := range validator.Helpers {
+			helpers[helper] = true
+		}
+
+		validators = append(validators, code.String())
+	}
+
+	return validators, nil
+}
+
+// packageCode holds a validator file of all the structs of a package, which
+// share the imports, regexps and helpers of the embedded validatorCode.
+type packageCode struct {
+	*validatorCode
+	Validators []*validatorCode
+}
+
+// GenerateValidatorFile generates a single file with the validators of all
+// the structs with validations. Unless already set, the nested structs are
+// the ones validated in the package.
+func (p *PackageInfo) GenerateValidatorFile() (string, error) {
+	nestedStructs := map[string]bool{}
+	for _, structInfo := range p.Structs {
+		if structInfo.HasValidateTag {
+			nestedStructs[structInfo.Name] = true
+		}
+	}
+
+	file := &packageCode{validatorCode: &validatorCode{}}
+	header := &StructInfo{PackageName: p.PackageName, DeclareSentinel: p.DeclareSentinel}
+	var errs []error
+	for _, structInfo := range p.Structs {
+		if !structInfo.HasValidateTag {
+			continue
+		}
+
+		if structInfo.NestedStructs == nil {
+			structInfo.NestedStructs = nestedStructs
+		}
+
+		if p.DeclareSentinel && len(file.Validators) > 0 && structInfo.Sentinel() != header.Sentinel() {
+			return "", fmt.Errorf("struct %s: sentinel %s differs from %s declared in the package", structInfo.Name, structInfo.Sentinel(), header.Sentinel())
+		}
+		header.ErrSentinel = structInfo.ErrSentinel
+		header.ErrSentinelImport = structInfo.ErrSentinelImport
+		if err := structInfo.checkModes(); err != nil {
+			return "", err
+		}
+		if err := structInfo.checkSentinel(); err != nil {
+			return "", err
+		}
+		if structInfo.DeclaresErrorType() {
+			header.StructuredErrors = true
+		}
+
+		if validateErrs := structInfo.Validate(); len(validateErrs) > 0 {
+			errs = append(errs, validateErrs...)
+			continue
+		}
+
+		// The checks of every struct add their pieces to the shared file.
+		file.StructInfo = &structInfo
+		checks, err := file.structChecks()
+		if err != nil {
+			return "", err
+		}
+
+		file.Validators = append(file.Validators, &validatorCode{StructInfo: &structInfo, Checks: checks})
+	}
+	if len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+
+	// The file header is generated from the package settings.
+	file.StructInfo = header
+	if file.DeclareSentinel {
+		if strings.Contains(file.Sentinel(), ".") {
+			return "", fmt.Errorf("sentinel %s of another package cannot be declared", file.Sentinel())
+		}
+
+		file.addImport("errors")
+	}
+
+	sort.Strings(file.Imports)
+
+	tmpl, err := template.New("PackageValidator").Parse(validatorHeaderTpl + "{{range .Validators}}" + validatorFuncTpl + "{{end}}")
+	if err != nil {
+		return "", err
+	}
+
+	code := new(bytes.Buffer)
+	if err := tmpl.Execute(code, file); err != nil {
+		return "", err
+	}
+
+	return formatCode(code.Bytes())
+}
+
+// registryEntry is a validator dispatched by the struct name.
+type registryEntry struct {
+	Name string
+	Call string
+}
+
+// GenerateRegistry generates a map from the struct names to their
+// validators, receiving the object as an interface{}.
+func (p *PackageInfo) GenerateRegistry() (string, error) {
+	var entries []registryEntry
+	for i := range p.Structs {
+		structInfo := &p.Structs[i]
+		if !structInfo.HasValidateTag {
+			continue
+		}
+
+		if structInfo.JoinErrors {
+			return "", fmt.Errorf("struct %s: registry requires validators returning []error", structInfo.Name)
+		}
+
+		validator := &validatorCode{StructInfo: structInfo}
+		argument := fmt.Sprintf("obj.(%s)", validator.Receiver())
+		entries = append(entries, registryEntry{
+			Name: structInfo.Name,
+			Call: validator.ValidatorCall(structInfo.Name, argument),
+		})
+	}
+
+	tmpl, err := template.New("Registry").Parse(registryTpl)
+	if err != nil {
+		return "", err
+	}
+
+	code := new(bytes.Buffer)
+	err = tmpl.Execute(code, struct {
+		PackageName string
+		Entries     []registryEntry
+	}{p.PackageName, entries})
+	if err != nil {
+		return "", err
+	}
+
+	return formatCode(code.Bytes())
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+func TestPackageInfoGenerateRegistry(t *testing.T) {
+	p := PackageInfo{
+		PackageName: "main",
+		Structs: []StructInfo{
+			{
+				Name:           "User",
+				FieldsInfo:     []FieldInfo{{Name: "FirstName", Type: "string", Validations: []string{"required"}}},
+				HasValidateTag: true,
+			},
+			{
+				Name: "NoValidateInfo",
+			},
+			{
+				Name:           "Address",
+				FieldsInfo:     []FieldInfo{{Name: "Street", Type: "string", Validations: []string{"required"}}},
+				HasValidateTag: true,
+				ByValue:        true,
+			},
+		},
+	}
+
+	want := `package main
+
+// Validators maps the struct names to their validators.
+var Validators = map[string]func(interface{}) []error{
+	"User":    func(obj interface{}) []error { return UserValidate(obj.(*User)) },
+	"Address": func(obj interface{}) []error { return AddressValidate(obj.(Address)) },
+}
+`
+
+	got, err := p.GenerateRegistry()
+	if err != nil {
+		t.Fatalf("PackageInfo.GenerateRegistry() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("PackageInfo.GenerateRegistry() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestPackageInfoGenerateValidatorsDeclareSentinel(t *testing.T) {
+	p := PackageInfo{
+		PackageName: "main",
+		Structs: []StructInfo{
+			{
+				Name:           "User",
+				PackageName:    "main",
+				FieldsInfo:     []FieldInfo{{Name: "FirstName", Type: "string", Validations: []string{"required"}}},
+				HasValidateTag: true,
+			},
+			{
+				Name:        "NoValidateInfo",
+				PackageName: "main",
+			},
+			{
+				Name:           "Address",
+				PackageName:    "main",
+				FieldsInfo:     []FieldInfo{{Name: "Street", Type: "string", Validations: []string{"required"}}},
+				HasValidateTag: true,
+			},
+		},
+		DeclareSentinel: true,
+	}
+
+	validators, err := p.GenerateValidators()
+	if err != nil {
+		t.Fatalf("PackageInfo.GenerateValidators() error = %v", err)
+	}
+	if len(validators) != 2 {
+		t.Fatalf("PackageInfo.GenerateValidators() = %d validators, want 2", len(validators))
+	}
+
+	declaration := `var ErrValidation = errors.New("validation error")`
+	if got := strings.Count(strings.Join(validators, "\n"), declaration); got != 1 {
+		t.Errorf("PackageInfo.GenerateValidators() declares the sentinel %d times, want 1", got)
+	}
+	if !strings.Contains(validators[0], declaration) || !strings.Contains(validators[0], `"errors"`) {
+		t.Errorf("PackageInfo.GenerateValidators() first validator = %v, want the sentinel declaration", validators[0])
+	}
+	if strings.Contains(validators[1], `"errors"`) {
+		t.Errorf("PackageInfo.GenerateValidators() second validator = %v, want no errors import", validators[1])
+	}
+}
+
+func TestPackageInfoGenerateValidatorsStructuredErrors(t *testing.T) {
+	p := PackageInfo{
+		PackageName: "main",
+		Structs: []StructInfo{
+			{
+				Name:             "User",
+				PackageName:      "main",
+				FieldsInfo:       []FieldInfo{{Name: "FirstName", Type: "string", Validations: []string{"required"}}},
+				HasValidateTag:   true,
+				StructuredErrors: true,
+			},
+			{
+				Name:             "Address",
+				PackageName:      "main",
+				FieldsInfo:       []FieldInfo{{Name: "Street", Type: "string", Validations: []string{"required"}}},
+				HasValidateTag:   true,
+				StructuredErrors: true,
+			},
+		},
+		DeclareSentinel: true,
+	}
+
+	validators, err := p.GenerateValidators()
+	if err != nil {
+		t.Fatalf("PackageInfo.GenerateValidators() error = %v", err)
+	}
+
+	declaration := "type ValidationError struct"
+	if got := strings.Count(strings.Join(validators, "\n"), declaration); got != 1 {
+		t.Errorf("PackageInfo.GenerateValidators() declares ValidationError %d times, want 1", got)
+	}
+
+	// The validators compile on their own, without the Generate output.
+	codes := map[string]string{"types.go": "package main\n\ntype User struct{ FirstName string }\n\ntype Address struct{ Street string }\n"}
+	for i, validator := range validators {
+		codes[fmt.Sprintf("validator%d.go", i)] = validator
+	}
+	if err := typeCheck(codes); err != nil {
+		t.Errorf("PackageInfo.GenerateValidators() does not compile: %v", err)
+	}
+
+	file, err := p.GenerateValidatorFile()
+	if err != nil {
+		t.Fatalf("PackageInfo.GenerateValidatorFile() error = %v", err)
+	}
+	if got := strings.Count(file, declaration); got != 1 {
+		t.Errorf("PackageInfo.GenerateValidatorFile() declares ValidationError %d times, want 1", got)
+	}
+}
+
+func TestPackageInfoGenerateValidatorsSharedDeclarations(t *testing.T) {
+	p := PackageInfo{
+		PackageName: "main",
+		Structs: []StructInfo{
+			{
+				Name:        "User",
+				PackageName: "main",
+				FieldsInfo: []FieldInfo{
+					{Name: "ID", Type: "string", Validations: []string{"uuid"}},
+					{Name: "Card", Type: "string", Validations: []string{"credit_card"}},
+					{Name: "Code", Type: "string", Validations: []string{"regexp=^[a-z]+$"}},
+				},
+				HasValidateTag: true,
+			},
+			{
+				Name:        "Order",
+				PackageName: "main",
+				FieldsInfo: []FieldInfo{
+					{Name: "ID", Type: "string", Validations: []string{"uuid"}},
+					{Name: "Card", Type: "string", Validations: []string{"credit_card"}},
+					{Name: "Code", Type: "string", Validations: []string{"regexp=^[0-9]+$"}},
+					{Name: "Ref", Type: "string", Validations: []string{"regexp=^[a-z]+$"}},
+				},
+				HasValidateTag: true,
+			},
+		},
+		DeclareSentinel: true,
+	}
+
+	validators, err := p.GenerateValidators()
+	if err != nil {
+		t.Fatalf("PackageInfo.GenerateValidators() error = %v", err)
+	}
+
+	for _, declaration := range []string{"var uuidRegexp", "func luhnValid", "var fieldPattern0", "var fieldPattern1"} {
+		if got := strings.Count(strings.Join(validators, "\n"), declaration); got != 1 {
+			t.Errorf("PackageInfo.GenerateValidators() declares %q %d times, want 1", declaration, got)
+		}
+	}
+	if !strings.Contains(validators[1], "fieldPattern0.MatchString(obj.Ref)") {
+		t.Errorf("PackageInfo.GenerateValidators() second validator = %v, want the declared regexp reused", validators[1])
+	}
+
+	codes := map[string]string{"types.go": "package main\n\ntype User struct{ ID, Card, Code string }\n\ntype Order struct{ ID, Card, Code, Ref string }\n"}
+	for i, validator := range validators {
+		codes[fmt.Sprintf("validator%d.go", i)] = validator
+	}
+	if err := typeCheck(codes); err != nil {
+		t.Errorf("PackageInfo.GenerateValidators() does not compile: %v", err)
+	}
+}
+
+func TestPackageInfoGenerateValidatorFile(t *testing.T) {
+	p := PackageInfo{
+		PackageName: "main",
+		Structs: []StructInfo{
+			{
+				Name:        "User",
+				PackageName: "main",
+				FieldsInfo: []FieldInfo{
+					{Name: "Email", Type: "string", Validations: []string{"email"}},
+					{Name: "ID", Type: "string", Validations: []string{"uuid"}},
+					{Name: "Address", Type: "Address", Validations: []string{"required"}},
+				},
+				HasValidateTag: true,
+			},
+			{
+				Name: "NoValidateInfo",
+			},
+			{
+				Name:        "Address",
+				PackageName: "main",
+				FieldsInfo: []FieldInfo{
+					{Name: "ID", Type: "string", Validations: []string{"uuid"}},
+					{Name: "Owner", Type: "*User"},
+				},
+				HasValidateTag: true,
+			},
+		},
+		DeclareSentinel: true,
+	}
+
+	want := `package main
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+)
+
+var ErrValidation = errors.New("validation error")
+
+var uuidRegexp = regexp.MustCompile(` + "`" + uuidPattern + "`" + `)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if _, err := mail.ParseAddress(obj.Email); err != nil {
+		errs = append(errs, fmt.Errorf("%w: Email must be a valid email", ErrValidation))
+	}
+
+	if !uuidRegexp.MatchString(obj.ID) {
+		errs = append(errs, fmt.Errorf("%w: ID must be a valid UUID", ErrValidation))
+	}
+
+	for _, err := range AddressValidate(&obj.Address) {
+		errs = append(errs, fmt.Errorf("Address: %w", err))
+	}
+
+	return errs
+}
+
+// AddressValidate validates a Address and returns all validation errors.
+func AddressValidate(obj *Address) []error {
+	var errs []error
+
+	if !uuidRegexp.MatchString(obj.ID) {
+		errs = append(errs, fmt.Errorf("%w: ID must be a valid UUID", ErrValidation))
+	}
+
+	if obj.Owner != nil {
+		for _, err := range UserValidate(obj.Owner) {
+			errs = append(errs, fmt.Errorf("Owner: %w", err))
+		}
+	}
+
+	return errs
+}
+`
+
+	got, err := p.GenerateValidatorFile()
+	if err != nil {
+		t.Fatalf("PackageInfo.GenerateValidatorFile() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("PackageInfo.GenerateValidatorFile() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+
+	p.Structs[2].ErrSentinel = "ErrInvalidAddress"
+	if _, err := p.GenerateValidatorFile(); err == nil {
+		t.Errorf("PackageInfo.GenerateValidatorFile() expected an error declaring different sentinels")
+	}
+}
@@ -5,13 +5,17 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 )
 
-func parseFile(fullpath string) ([]StructInfo, error) {
+const defaultTagName = "validate"
+
+func parseFile(fullpath, tagName string) ([]StructInfo, error) {
 	fmt.Printf("Parsing %s\n", fullpath)
 
 	src, err := os.ReadFile(fullpath)
@@ -19,15 +23,69 @@ func parseFile(fullpath string) ([]StructInfo, error) {
 		return nil, err
 	}
 
-	structs, err := parseStructs(fullpath, string(src))
+	structs, err := parseStructs(fullpath, string(src), tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	namedTypes, err := packageNamedTypes(filepath.Dir(fullpath))
 	if err != nil {
 		return nil, err
 	}
 
+	if len(namedTypes) > 0 {
+		for i := range structs {
+			structs[i].NamedTypes = namedTypes
+		}
+	}
+
 	return structs, nil
 }
 
-func parseStructs(fullpath, src string) ([]StructInfo, error) {
+// packageNamedTypes returns the underlying types of the named non struct
+// types declared in all the files of the package in dir.
+func packageNamedTypes(dir string) (map[string]string, error) {
+	filenames, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	namedTypes := map[string]string{}
+	fset := token.NewFileSet()
+	for _, filename := range filenames {
+		if strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, filename, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		addNamedTypes(f, namedTypes)
+	}
+
+	return namedTypes, nil
+}
+
+// addNamedTypes adds the underlying types of the named non struct types
+// declared in the file.
+func addNamedTypes(f *ast.File, namedTypes map[string]string) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		if typeSpec, ok := n.(*ast.TypeSpec); ok {
+			if _, isStruct := typeSpec.Type.(*ast.StructType); !isStruct {
+				namedTypes[typeSpec.Name.Name] = types.ExprString(typeSpec.Type)
+			}
+		}
+
+		return true
+	})
+}
+
+func parseStructs(fullpath, src, tagName string) ([]StructInfo, error) {
+	if tagName == "" {
+		tagName = defaultTagName
+	}
 
 	filename := filepath.Base(fullpath)
 
@@ -38,6 +96,8 @@ func parseStructs(fullpath, src string) ([]StructInfo, error) {
 	}
 
 	var structs []StructInfo
+	namedTypes := map[string]string{}
+	addNamedTypes(f, namedTypes)
 	packageName := ""
 
 	ast.Inspect(f, func(n ast.Node) bool {
@@ -50,6 +110,7 @@ func parseStructs(fullpath, src string) ([]StructInfo, error) {
 				Name:        typeSpec.Name.Name,
 				Path:        "./" + filepath.Dir(fullpath),
 				PackageName: packageName,
+				TagName:     tagName,
 			})
 		}
 
@@ -57,7 +118,7 @@ func parseStructs(fullpath, src string) ([]StructInfo, error) {
 			currentStruct := &structs[len(structs)-1]
 
 			for _, field := range structType.Fields.List {
-				fieldType := field.Type.(*ast.Ident).Name
+				fieldType := types.ExprString(field.Type)
 
 				fieldTag := ""
 				if field.Tag != nil {
@@ -65,17 +126,31 @@ func parseStructs(fullpath, src string) ([]StructInfo, error) {
 					fieldTag, _ = strconv.Unquote(fieldTag)
 				}
 
-				fieldValidations, hasValidateTag := parseFieldValidations(fieldTag)
+				fieldValidations, hasValidateTag := parseFieldValidations(fieldTag, tagName)
+				skip := isSkippedField(fieldTag, tagName)
 				if hasValidateTag {
 					currentStruct.HasValidateTag = true
 				}
 
+				var names []string
 				for _, name := range field.Names {
+					names = append(names, name.Name)
+				}
+
+				embedded := len(names) == 0
+				if embedded {
+					names = append(names, embeddedFieldName(fieldType))
+				}
+
+				for _, name := range names {
 					currentStruct.FieldsInfo = append(currentStruct.FieldsInfo, FieldInfo{
-						Name:        name.Name,
+						Name:        name,
 						Type:        fieldType,
 						Tag:         fieldTag,
 						Validations: fieldValidations,
+						JSONName:    parseJSONName(fieldTag),
+						Skip:        skip,
+						Embedded:    embedded,
 					})
 				}
 			}
@@ -84,19 +159,142 @@ func parseStructs(fullpath, src string) ([]StructInfo, error) {
 		return true
 	})
 
+	if len(namedTypes) > 0 {
+		for i := range structs {
+			structs[i].NamedTypes = namedTypes
+		}
+	}
+
 	return structs, nil
 }
 
-func parseFieldValidations(fieldTag string) ([]string, bool) {
+// parseFieldValidations splits the validations of the field tag. The rules are
+// separated by "," or "|", which are equivalent. The custom message is always
+// the trailing rule and keeps both separators as part of its text. The rules
+// of several tag keys, separated by commas in tagName, are merged in order,
+// keeping the custom message last.
+func parseFieldValidations(fieldTag, tagName string) ([]string, bool) {
 	fieldValidations := []string{}
 	hasValidateTag := false
+	message := ""
+
+	for _, tagKey := range strings.Split(tagName, ",") {
+		tagValue, ok := reflect.StructTag(fieldTag).Lookup(tagKey)
+		if !ok || tagValue == "-" {
+			continue
+		}
 
-	if strings.HasPrefix(fieldTag, "validate:") {
 		hasValidateTag = true
-		tagWithoutPrefix, _ := strings.CutPrefix(fieldTag, "validate:")
-		tagWithoutQuotes, _ := strconv.Unquote(tagWithoutPrefix)
-		fieldValidations = strings.Split(tagWithoutQuotes, ",")
+		for _, fieldValidation := range splitValidations(tagValue) {
+			fieldValidation = trimValidation(fieldValidation)
+			if strings.HasPrefix(fieldValidation, "msg=") {
+				message = fieldValidation
+				continue
+			}
+
+			fieldValidations = append(fieldValidations, fieldValidation)
+		}
+	}
+
+	if message != "" {
+		fieldValidations = append(fieldValidations, message)
 	}
 
 	return fieldValidations, hasValidateTag
 }
+
+// trimValidation removes the spaces around the rule. An argument made only of
+// spaces, as in "excludes= ", is kept.
+func trimValidation(fieldValidation string) string {
+	validation, args, found := strings.Cut(strings.TrimLeft(fieldValidation, " "), "=")
+	if found && args != "" && strings.TrimSpace(args) == "" {
+		return strings.TrimSpace(validation) + "=" + args
+	}
+
+	return strings.TrimSpace(fieldValidation)
+}
+
+// textArgumentValidations take free text arguments, in which "|" is part of
+// the argument instead of a rule separator.
+var textArgumentValidations = map[string]bool{
+	"regexp":      true,
+	"contains":    true,
+	"excludes":    true,
+	"containsany": true,
+	"startswith":  true,
+	"endswith":    true,
+}
+
+// splitValidations splits the tag value on the rule separators, stopping at
+// the custom message. The comma between the bounds of between is kept, and
+// so is a "|" in the argument of the validations taking free text.
+func splitValidations(tagValue string) []string {
+	var fieldValidations []string
+
+	for {
+		if strings.HasPrefix(strings.TrimSpace(tagValue), "msg=") {
+			return append(fieldValidations, tagValue)
+		}
+
+		validation, _, _ := strings.Cut(strings.TrimSpace(tagValue), "=")
+		separators := ",|"
+		if textArgumentValidations[validation] {
+			separators = ","
+		}
+
+		i := strings.IndexAny(tagValue, separators)
+		if i < 0 {
+			return append(fieldValidations, tagValue)
+		}
+
+		// The bounds of between are separated by a comma too.
+		if tagValue[i] == ',' && strings.HasPrefix(strings.TrimSpace(tagValue[:i]), "between=") {
+			next := strings.IndexAny(tagValue[i+1:], ",|")
+			if next < 0 {
+				return append(fieldValidations, tagValue)
+			}
+			i += next + 1
+		}
+
+		fieldValidations = append(fieldValidations, tagValue[:i])
+		tagValue = tagValue[i+1:]
+	}
+}
+
+// embeddedFieldName returns the name of an embedded field, which is its type
+// without the pointer and the package qualifier.
+func embeddedFieldName(fieldType string) string {
+	name := strings.TrimPrefix(fieldType, "*")
+	if _, typeName, qualified := strings.Cut(name, "."); qualified {
+		return typeName
+	}
+
+	return name
+}
+
+// isSkippedField reports whether the field tag explicitly excludes the field
+// from the validation with "-", in any of the tag keys.
+func isSkippedField(fieldTag, tagName string) bool {
+	for _, tagKey := range strings.Split(tagName, ",") {
+		if tagValue, _ := reflect.StructTag(fieldTag).Lookup(tagKey); tagValue == "-" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseJSONName returns the field name from the json tag, without options.
+func parseJSONName(fieldTag string) string {
+	tagValue, ok := reflect.StructTag(fieldTag).Lookup("json")
+	if !ok {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(tagValue, ",")
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
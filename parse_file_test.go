@@ -0,0 +1,253 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFieldValidations(t *testing.T) {
+	tests := []struct {
+		name               string
+		fieldTag           string
+		wantValidations    []string
+		wantHasValidateTag bool
+	}{
+		{
+			name:               "Without validate tag",
+			fieldTag:           `json:"name"`,
+			wantValidations:    []string{},
+			wantHasValidateTag: false,
+		},
+		{
+			name:               "Several validations",
+			fieldTag:           `validate:"required,gte=5"`,
+			wantValidations:    []string{"required", "gte=5"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Validate tag after other tags",
+			fieldTag:           `json:"name" validate:"required"`,
+			wantValidations:    []string{"required"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Custom message with commas",
+			fieldTag:           `validate:"required,msg=Name, first and last, is mandatory"`,
+			wantValidations:    []string{"required", "msg=Name, first and last, is mandatory"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Spaces around validations",
+			fieldTag:           `validate:" required, gte=5 ,lte=10, msg=Name, first and last, is mandatory "`,
+			wantValidations:    []string{"required", "gte=5", "lte=10", "msg=Name, first and last, is mandatory"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Negative bounds",
+			fieldTag:           `validate:"gte=-40, lte=-5"`,
+			wantValidations:    []string{"gte=-40", "lte=-5"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Argument made of spaces",
+			fieldTag:           `validate:"required, excludes= ,lte=10"`,
+			wantValidations:    []string{"required", "excludes= ", "lte=10"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Pipe separated validations",
+			fieldTag:           `validate:"required|gte=5|lte=10"`,
+			wantValidations:    []string{"required", "gte=5", "lte=10"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Mixed separators",
+			fieldTag:           `validate:"required|oneof=a b,lte=10 | msg=Name, a|b, is mandatory"`,
+			wantValidations:    []string{"required", "oneof=a b", "lte=10", "msg=Name, a|b, is mandatory"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Between bounds",
+			fieldTag:           `validate:"required,between=5,10|msg=Age is invalid"`,
+			wantValidations:    []string{"required", "between=5,10", "msg=Age is invalid"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Trailing between bounds",
+			fieldTag:           `validate:"between=5,10"`,
+			wantValidations:    []string{"between=5,10"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Regexp with alternation",
+			fieldTag:           `validate:"required|regexp=^(a|b)$,lte=10"`,
+			wantValidations:    []string{"required", "regexp=^(a|b)$", "lte=10"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Pipe in text arguments",
+			fieldTag:           `validate:"containsany=|!,excludes=|"`,
+			wantValidations:    []string{"containsany=|!", "excludes=|"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Skipped field",
+			fieldTag:           `validate:"-"`,
+			wantValidations:    []string{},
+			wantHasValidateTag: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValidations, gotHasValidateTag := parseFieldValidations(tt.fieldTag, defaultTagName)
+			if !reflect.DeepEqual(gotValidations, tt.wantValidations) {
+				t.Errorf("parseFieldValidations() validations = %q, want %q", gotValidations, tt.wantValidations)
+			}
+			if gotHasValidateTag != tt.wantHasValidateTag {
+				t.Errorf("parseFieldValidations() hasValidateTag = %v, want %v", gotHasValidateTag, tt.wantHasValidateTag)
+			}
+		})
+	}
+}
+
+func TestParseStructsTagName(t *testing.T) {
+	src := `package main
+
+type User struct {
+	FirstName string ` + "`binding:\"required\"`" + `
+	LastName  string ` + "`validate:\"required\"`" + `
+}
+`
+
+	structs, err := parseStructs("tests/user.go", src, "binding")
+	if err != nil {
+		t.Fatalf("parseStructs() error = %v", err)
+	}
+
+	want := []StructInfo{
+		{
+			Name:        "User",
+			Path:        "./tests",
+			PackageName: "main",
+			FieldsInfo: []FieldInfo{
+				{
+					Name:        "FirstName",
+					Type:        "string",
+					Tag:         `binding:"required"`,
+					Validations: []string{"required"},
+				},
+				{
+					Name:        "LastName",
+					Type:        "string",
+					Tag:         `validate:"required"`,
+					Validations: []string{},
+				},
+			},
+			HasValidateTag: true,
+			TagName:        "binding",
+		},
+	}
+	if !reflect.DeepEqual(structs, want) {
+		t.Errorf("parseStructs() = %+v, want %+v", structs, want)
+	}
+}
+
+func TestParseFieldValidationsMultipleTags(t *testing.T) {
+	tests := []struct {
+		name               string
+		fieldTag           string
+		wantValidations    []string
+		wantHasValidateTag bool
+	}{
+		{
+			name:               "Merged tags",
+			fieldTag:           `validate:"required" validate_extra:"email"`,
+			wantValidations:    []string{"required", "email"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Custom message stays last",
+			fieldTag:           `validate:"required,msg=Email is invalid" validate_extra:"email"`,
+			wantValidations:    []string{"required", "email", "msg=Email is invalid"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "Only the second tag",
+			fieldTag:           `validate_extra:"email"`,
+			wantValidations:    []string{"email"},
+			wantHasValidateTag: true,
+		},
+		{
+			name:               "No tags",
+			fieldTag:           `json:"email"`,
+			wantValidations:    []string{},
+			wantHasValidateTag: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotValidations, gotHasValidateTag := parseFieldValidations(tt.fieldTag, "validate,validate_extra")
+			if !reflect.DeepEqual(gotValidations, tt.wantValidations) {
+				t.Errorf("parseFieldValidations() validations = %q, want %q", gotValidations, tt.wantValidations)
+			}
+			if gotHasValidateTag != tt.wantHasValidateTag {
+				t.Errorf("parseFieldValidations() hasValidateTag = %v, want %v", gotHasValidateTag, tt.wantHasValidateTag)
+			}
+		})
+	}
+
+	if !isSkippedField(`validate:"required" validate_extra:"-"`, "validate,validate_extra") {
+		t.Errorf("isSkippedField() = false, want true")
+	}
+}
+
+func TestEmbeddedFieldName(t *testing.T) {
+	for fieldType, want := range map[string]string{
+		"User":        "User",
+		"*User":       "User",
+		"models.User": "User",
+	} {
+		if got := embeddedFieldName(fieldType); got != want {
+			t.Errorf("embeddedFieldName(%s) = %s, want %s", fieldType, got, want)
+		}
+	}
+}
+
+func TestParseJSONName(t *testing.T) {
+	tests := []struct {
+		name     string
+		fieldTag string
+		want     string
+	}{
+		{
+			name:     "Without json tag",
+			fieldTag: `validate:"required"`,
+			want:     "",
+		},
+		{
+			name:     "With json tag",
+			fieldTag: `json:"first_name" validate:"required"`,
+			want:     "first_name",
+		},
+		{
+			name:     "With json tag options",
+			fieldTag: `json:"name,omitempty"`,
+			want:     "name",
+		},
+		{
+			name:     "Ignored by json",
+			fieldTag: `json:"-"`,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseJSONName(tt.fieldTag); got != tt.want {
+				t.Errorf("parseJSONName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,24 +2,67 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"go/format"
+	"io"
 	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 )
 
-var structValidatorTpl = `package {{.PackageName}}
+var validatorHeaderTpl = `{{if .BuildTags}}//go:build {{.BuildConstraint " && "}}
+// +build {{.BuildConstraint ","}}
 
+{{end}}package {{.PackageName}}
+{{if .Imports}}
 import (
-	"fmt"
-)
+{{range .Imports}}	"{{.}}"
+{{end}})
+{{end}}{{if .DeclareSentinel}}
+var {{.Sentinel}} = errors.New("validation error")
+{{end}}{{if .DeclaresErrorType}}
+// ValidationError describes a field that failed a validation.
+type ValidationError struct {
+	Field   string
+	Tag     string
+	Message string
+}
 
-func {{.Name}}Validate(obj *{{.Name}}) []error {
-	var errs []error
-{{range .FieldsInfo}}{{condition .Name .Type .Validations}}{{end}}
-	return errs
+func (e *ValidationError) Error() string {
+	return {{.Sentinel}}.Error() + ": " + e.Message
 }
-`
+
+func (e *ValidationError) Unwrap() error {
+	return {{.Sentinel}}
+}
+{{end}}{{range .Regexps}}
+var {{.Name}} = regexp.MustCompile({{.Literal}})
+{{end}}{{range .Helpers}}
+{{.}}
+{{end}}`
+
+var validatorFuncTpl = `
+// {{.FuncName "Validate"}} validates a {{.Name}} and returns {{if .FailFast}}the first validation error{{else}}all validation errors{{end}}.
+func {{.FuncDecl "Validate" .ValidateInto}} {{.ReturnType}} {
+{{.NilGuard}}{{if .FailFast}}{{.Checks}}
+	return {{if .ValidateInto}}errs{{else}}nil{{end}}
+{{else}}{{if not .ValidateInto}}	var errs []error
+{{end}}{{.Checks}}
+	return {{if .JoinErrors}}errors.Join(errs...){{else}}errs{{end}}
+{{end}}}
+{{if .IsValidHelper}}
+// {{.FuncName "IsValid"}} reports whether a {{.Name}} passes all validations.
+func {{.FuncDecl "IsValid" false}} bool {
+	return {{if .JoinErrors}}{{.ValidatorCall .Name "obj"}} == nil{{else}}len({{.ValidatorCall .Name "obj"}}) == 0{{end}}
+}
+{{end}}`
+
+var structValidatorTpl = validatorHeaderTpl + validatorFuncTpl
 
 var packageDefinitionTpl = `package {{.PackageName}}
 
@@ -27,15 +70,198 @@ import (
 	"errors"
 )
 
-var ErrValidation = errors.New("validation error")
+var {{.Sentinel}} = errors.New("validation error")
 `
 
+const (
+	uuidPattern        = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+	alphaPattern       = `^[a-zA-Z]+$`
+	alphanumPattern    = `^[a-zA-Z0-9]+$`
+	numericPattern     = `^[0-9]+$`
+	hexadecimalPattern = `^[0-9a-fA-F]+$`
+	base64Pattern      = `^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=)?$`
+	asciiPattern       = `^[\x00-\x7F]*$`
+	printasciiPattern  = `^[\x20-\x7E]*$`
+	semverPattern      = `^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`
+	e164Pattern        = `^\+[1-9]\d{1,14}$`
+	hostnamePattern    = `^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`
+	fqdnPattern        = `^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]{2,63}\.?$`
+	rgbPattern         = `^rgb\(\s*(?:(?:0|[1-9]\d?|1\d\d?|2[0-4]\d|25[0-5])\s*,\s*){2}(?:0|[1-9]\d?|1\d\d?|2[0-4]\d|25[0-5])\s*\)$`
+	hexcolorPattern    = `^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`
+	slugPattern        = `^[a-z0-9]+(?:-[a-z0-9]+)*$`
+	ulidPattern        = `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`
+	rgbaPattern        = `^rgba\(\s*(?:(?:0|[1-9]\d?|1\d\d?|2[0-4]\d|25[0-5])\s*,\s*){3}(?:0|1|0?\.\d+|1\.0+)\s*\)$`
+)
+
+// helperFuncs holds the code of the functions used by validations that are
+// not expressible as a single condition.
+var helperFuncs = map[string]string{
+	"hasDuplicates": `// hasDuplicates reports whether some value appears more than once.
+func hasDuplicates[T comparable](values []T) bool {
+	seen := make(map[T]struct{}, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			return true
+		}
+		seen[value] = struct{}{}
+	}
+
+	return false
+}`,
+	"luhnValid": `// luhnValid reports whether s is a number passing the Luhn checksum.
+func luhnValid(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+
+		digit := int(s[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+
+	return sum%10 == 0
+}`,
+}
+
+// ValidationFunc builds the test elements of a custom validation, usually
+// with NewFieldTestElements. The returned elements may use the same {{.Name}}
+// and {{.Target}} placeholders as the built-in validations.
+type ValidationFunc func(fieldName, arg, fieldType string) (FieldTestElements, error)
+
+var customValidations = map[string]ValidationFunc{}
+
+// NewFieldTestElements returns the test elements of a custom validation that
+// fails when the condition is true. The condition may use the packages listed
+// in imports.
+func NewFieldTestElements(condition, errorMessage string, imports ...string) FieldTestElements {
+	return FieldTestElements{condition: condition, errorMessage: errorMessage, imports: imports}
+}
+
+// RegisterValidation adds a custom validation, which takes precedence over a
+// built-in validation with the same name.
+func RegisterValidation(name string, fn ValidationFunc) {
+	customValidations[name] = fn
+}
+
+// crossFieldValidations compare the field to another field of the struct.
+var crossFieldValidations = map[string]bool{
+	"eqfield":  true,
+	"nefield":  true,
+	"gtfield":  true,
+	"ltfield":  true,
+	"gtefield": true,
+	"ltefield": true,
+}
+
+// conditionalValidations depend on the values of other fields of the struct.
+var conditionalValidations = map[string]bool{
+	"required_if":          true,
+	"required_with":        true,
+	"required_without":     true,
+	"required_with_all":    true,
+	"required_without_all": true,
+	"excluded_if":          true,
+}
+
 type StructInfo struct {
 	Name           string
 	Path           string
 	PackageName    string
 	FieldsInfo     []FieldInfo
 	HasValidateTag bool
+	TagName        string          // Struct tag keys holding the validations, separated by commas.
+	CountRunes     bool            // String lengths are counted in runes instead of bytes.
+	NestedStructs  map[string]bool // Struct types that have a generated validator.
+
+	// StructuredErrors reports *ValidationError values instead of wrapped
+	// ErrValidation errors. The ValidationError type is declared by the
+	// validator, unless ErrorTypeDeclared by another file of the package.
+	StructuredErrors  bool
+	ErrorTypeDeclared bool
+
+	// DeclaredRegexps maps the patterns of the regexps declared by other
+	// files of the package to their variable names, and DeclaredHelpers holds
+	// the helper functions they declare. The validator reuses them.
+	DeclaredRegexps map[string]string
+	DeclaredHelpers map[string]bool
+
+	FailFast      bool              // Return on the first validation error.
+	IsValidHelper bool              // Also generate a boolean IsValid function.
+	ByValue       bool              // Validators receive the struct by value instead of a pointer.
+	AsMethod      bool              // Generate Validate methods instead of functions.
+	JoinErrors    bool              // Validators return a single error built with errors.Join.
+	ValidateInto  bool              // Validators append to an error slice provided by the caller.
+	FuseRanges    bool              // Fields with gte and lte get a single range check.
+	NamedTypes    map[string]string // Underlying types of the named non struct types.
+	BuildTags     []string          // Build tags required to compile the validator.
+
+	// ErrSentinel is the error wrapped by the validation errors, ErrValidation
+	// when empty. DeclareSentinel declares it in the validator file, and
+	// ErrSentinelImport imports the package of a qualified sentinel.
+	ErrSentinel       string
+	ErrSentinelImport string
+	DeclareSentinel   bool
+
+	PrefixStructName bool // Error messages name the fields as Struct.Field.
+	IncludeValue     bool // Error messages include the value that failed.
+	NilCheck         bool // Pointer validators report a nil object instead of panicking.
+}
+
+// DeclaresErrorType reports whether the validator declares the
+// ValidationError type.
+func (s *StructInfo) DeclaresErrorType() bool {
+	return s.StructuredErrors && !s.ErrorTypeDeclared
+}
+
+// Sentinel returns the name of the error wrapped by the validation errors.
+func (s *StructInfo) Sentinel() string {
+	if s.ErrSentinel == "" {
+		return "ErrValidation"
+	}
+
+	return s.ErrSentinel
+}
+
+// checkModes reports generation modes that cannot be combined.
+func (s *StructInfo) checkModes() error {
+	if s.ValidateInto && s.JoinErrors {
+		return fmt.Errorf("struct %s: validators appending to the errors of the caller cannot join them", s.Name)
+	}
+
+	return nil
+}
+
+// checkSentinel reports a sentinel that cannot be referenced by the
+// validator, as declared or imported.
+func (s *StructInfo) checkSentinel() error {
+	qualifier, _, qualified := strings.Cut(s.Sentinel(), ".")
+	switch {
+	case !qualified && s.ErrSentinelImport != "":
+		return fmt.Errorf("struct %s: sentinel %s is not qualified by the package of %s", s.Name, s.Sentinel(), s.ErrSentinelImport)
+	case !qualified:
+		return nil
+	case s.DeclareSentinel:
+		return fmt.Errorf("struct %s: sentinel %s of another package cannot be declared", s.Name, s.Sentinel())
+	case s.ErrSentinelImport == "":
+		return fmt.Errorf("struct %s: sentinel %s of another package requires its import path", s.Name, s.Sentinel())
+	case path.Base(s.ErrSentinelImport) != qualifier:
+		return fmt.Errorf("struct %s: sentinel %s is not qualified by the package of %s", s.Name, s.Sentinel(), s.ErrSentinelImport)
+	}
+
+	return nil
 }
 
 // TODO: NewFieldInfo to validate params and build the object.
@@ -44,127 +270,1652 @@ type FieldInfo struct {
 	Type        string
 	Tag         string
 	Validations []string
+	JSONName    string // Name from the json tag, used in the error messages.
+	Embedded    bool   // Anonymous field, named after its type.
+	Skip        bool   // Field explicitly excluded from validation with "-".
+}
+
+// DisplayName returns the name used to refer to the field in error messages.
+func (f FieldInfo) DisplayName() string {
+	if f.JSONName != "" {
+		return f.JSONName
+	}
+
+	return f.Name
+}
+
+// Skipped reports whether the field is excluded from the validation, either
+// by the parser or by a "-" validation.
+func (f FieldInfo) Skipped() bool {
+	return f.Skip || (len(f.Validations) == 1 && f.Validations[0] == "-")
 }
 
 type FieldTestElements struct {
 	loperand     string
 	operator     string
 	roperand     string
+	condition    string // Used instead of the operands when the test is not a simple comparison.
 	errorMessage string
+	imports      []string
+	regexpName   string // Package level variable holding the compiled regexp.
+	regexp       string
+	helper       string // Package level function used by the condition.
 }
 
-func (fv *StructInfo) GenerateValidator() (string, error) {
-	funcMap := template.FuncMap{
-		"condition": condition,
+// Condition returns the expression that is true when the validation fails.
+func (te FieldTestElements) Condition() string {
+	if te.condition != "" {
+		return te.condition
 	}
 
-	tmpl, err := template.New("FileValidator").Funcs(funcMap).Parse(structValidatorTpl)
-	if err != nil {
-		return "", err
+	return te.loperand + " " + te.operator + " " + te.roperand
+}
+
+// Operands returns the operands and the operator of the comparison that
+// fails the validation. They are empty when the test is not a comparison.
+func (te FieldTestElements) Operands() (string, string, string) {
+	return te.loperand, te.operator, te.roperand
+}
+
+// ErrorMessage returns the message of the validation error, as a format
+// string for fmt.Errorf.
+func (te FieldTestElements) ErrorMessage() string {
+	return te.errorMessage
+}
+
+// Imports returns the packages used by the condition.
+func (te FieldTestElements) Imports() []string {
+	return te.imports
+}
+
+// Regexp returns the name of the package level variable used by the
+// condition and its pattern, which are empty when no regexp is used.
+func (te FieldTestElements) Regexp() (string, string) {
+	return te.regexpName, te.regexp
+}
+
+// Helper returns the code of the package level function used by the
+// condition, or an empty string.
+func (te FieldTestElements) Helper() string {
+	return helperFuncs[te.helper]
+}
+
+// validatorCode holds the pieces of a validator file that depend on the
+// validations used by the struct fields.
+type validatorCode struct {
+	*StructInfo
+	Imports []string
+	Regexps []regexpVar
+	Helpers []string
+	Checks  string
+
+	patternCount int
+}
+
+// Receiver returns the type of the validated object parameter.
+func (vc *validatorCode) Receiver() string {
+	if vc.ByValue {
+		return vc.Name
 	}
 
-	code := new(bytes.Buffer)
-	if err := tmpl.Execute(code, fv); err != nil {
+	return "*" + vc.Name
+}
+
+// BuildConstraint returns the build tags joined by the and operator.
+func (vc *validatorCode) BuildConstraint(and string) string {
+	return strings.Join(vc.BuildTags, and)
+}
+
+// ReturnType returns the type returned by the validator.
+func (vc *validatorCode) ReturnType() string {
+	if vc.JoinErrors {
+		return "error"
+	}
+
+	return "[]error"
+}
+
+// FuncName returns the name of a generated function or method.
+func (vc *validatorCode) FuncName(name string) string {
+	if vc.AsMethod {
+		return name
+	}
+
+	return vc.Name + name
+}
+
+// FuncDecl returns the declaration of a generated function or method up to
+// its parameters, which may include the error slice to append to.
+func (vc *validatorCode) FuncDecl(name string, errsParam bool) string {
+	params := ""
+	if errsParam {
+		params = "errs []error"
+	}
+
+	if vc.AsMethod {
+		return "(obj " + vc.Receiver() + ") " + name + "(" + params + ")"
+	}
+
+	if params != "" {
+		params = ", " + params
+	}
+
+	return vc.Name + name + "(obj " + vc.Receiver() + params + ")"
+}
+
+// NilGuard returns the statement reporting a nil object, when NilCheck is set
+// and the validator receives a pointer.
+func (vc *validatorCode) NilGuard() string {
+	if !vc.NilCheck || vc.ByValue {
+		return ""
+	}
+
+	nilError := fmt.Sprintf("fmt.Errorf(%s, %s)", strconv.Quote("%w: nil "+vc.Name), vc.Sentinel())
+	if vc.StructuredErrors {
+		nilError = fmt.Sprintf("&ValidationError{Tag: %q, Message: %s}", "required", strconv.Quote("nil "+vc.Name))
+	}
+
+	result := "[]error{" + nilError + "}"
+	if vc.JoinErrors {
+		result = nilError
+	} else if vc.ValidateInto {
+		result = "append(errs, " + nilError + ")"
+	}
+
+	return "\tif obj == nil {\n\t\treturn " + result + "\n\t}\n\n"
+}
+
+// ValidatorCall returns the call to the validator of structName, receiving
+// the object argument as generated by the current mode.
+func (vc *validatorCode) ValidatorCall(structName, argument string) string {
+	errs := ""
+	if vc.ValidateInto {
+		errs = "nil"
+	}
+
+	if vc.AsMethod {
+		return argument + ".Validate(" + errs + ")"
+	}
+
+	if errs != "" {
+		errs = ", " + errs
+	}
+
+	return structName + "Validate(" + argument + errs + ")"
+}
+
+// regexpVar is a package level compiled regexp shared by the checks.
+type regexpVar struct {
+	Name    string
+	Pattern string
+}
+
+func (rv regexpVar) Literal() string {
+	if strconv.CanBackquote(rv.Pattern) {
+		return "`" + rv.Pattern + "`"
+	}
+
+	return strconv.Quote(rv.Pattern)
+}
+
+func (fv *StructInfo) GenerateValidator() (string, error) {
+	code := new(strings.Builder)
+	if err := fv.WriteValidator(code); err != nil {
 		return "", err
 	}
 
 	return code.String(), nil
 }
 
-func condition(fieldName, fieldType string, fieldValidations []string) (string, error) {
+// Validate checks that the validations of every field are compatible with
+// its type, returning all the incompatibilities found.
+func (fv *StructInfo) Validate() []error {
+	var errs []error
+	for _, fieldInfo := range fv.FieldsInfo {
+		if fieldInfo.Skipped() {
+			continue
+		}
 
-	tests := ""
-	for _, fieldValidation := range fieldValidations {
-		testElements, err := GetFieldTestElements(fieldName, fieldValidation, fieldType)
-		if err != nil {
-			return "", fmt.Errorf("field %s: %w", fieldName, err)
+		validator := &validatorCode{StructInfo: fv}
+		if _, err := validator.fieldChecks(fieldInfo); err != nil {
+			errs = append(errs, joinedErrors(err)...)
 		}
+	}
 
-		tests += fmt.Sprintf(
-			`
-	if !(%s %s %s) {
-		errs = append(errs, fmt.Errorf("%%w: %s", ErrValidation))
+	return errs
+}
+
+// joinedErrors returns the errors joined by errors.Join into err.
+func joinedErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
 	}
-`, testElements.loperand, testElements.operator, testElements.roperand, testElements.errorMessage)
+
+	var errs []error
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, joinedErrors(err)...)
 	}
 
-	return tests, nil
+	return errs
 }
 
-func GetFieldTestElements(fieldName, fieldValidation, fieldType string) (FieldTestElements, error) {
-	ifCode := map[string]FieldTestElements{
-		"required,string": {"{{.Name}}", "!=", `""`, "{{.Name}} required"},
-		"required,uint8":  {"{{.Name}}", "!=", `0`, "{{.Name}} required"},
-		"gte,uint8":       {"{{.Name}}", ">=", `{{.Target}}`, "{{.Name}} must be >= {{.Target}}"},
-		"lte,uint8":       {"{{.Name}}", "<=", `{{.Target}}`, "{{.Name}} must be <= {{.Target}}"},
-		"gte,string":      {"len({{.Name}})", ">=", `{{.Target}}`, "length {{.Name}} must be >= {{.Target}}"},
-		"lte,string":      {"len({{.Name}})", "<=", `{{.Target}}`, "length {{.Name}} must be <= {{.Target}}"},
+// WriteValidator writes the validator code to w.
+func (fv *StructInfo) WriteValidator(w io.Writer) error {
+	_, err := fv.writeValidator(w)
+	return err
+}
+
+// writeValidator writes the validator code to w, returning the pieces it
+// declares.
+func (fv *StructInfo) writeValidator(w io.Writer) (*validatorCode, error) {
+	if errs := fv.Validate(); len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
 
-	splitField := strings.Split(fieldValidation, "=")
-	validation := splitField[0]
-	target := ""
-	if len(splitField) > 1 {
-		target = splitField[1]
+	if err := fv.checkModes(); err != nil {
+		return nil, err
 	}
 
-	ifData, ok := ifCode[validation+","+fieldType]
-	if !ok {
-		return FieldTestElements{}, fmt.Errorf("unsupported validation %s type %s", fieldValidation, fieldType)
+	if err := fv.checkSentinel(); err != nil {
+		return nil, err
 	}
 
-	ifData.loperand = strings.Replace(ifData.loperand, "{{.Name}}", "obj."+fieldName, -1)
-	ifData.loperand = strings.Replace(ifData.loperand, "{{.Target}}", target, -1)
-	ifData.roperand = strings.Replace(ifData.roperand, "{{.Name}}", "obj."+fieldName, -1)
-	ifData.roperand = strings.Replace(ifData.roperand, "{{.Target}}", target, -1)
-	ifData.errorMessage = strings.Replace(ifData.errorMessage, "{{.Name}}", fieldName, -1)
-	ifData.errorMessage = strings.Replace(ifData.errorMessage, "{{.Target}}", target, -1)
+	validator := &validatorCode{
+		StructInfo: fv,
+	}
 
-	return ifData, nil
-}
+	checks, err := validator.structChecks()
+	if err != nil {
+		return nil, err
+	}
+	validator.Checks = checks
 
-func (s *StructInfo) GenerateFileValidator() error {
-	fmt.Printf("Generating struct %s validations code\n", s.Name)
+	if fv.DeclareSentinel {
+		validator.addImport("errors")
+	}
 
-	code, err := s.GenerateValidator()
+	sort.Strings(validator.Imports)
+
+	tmpl, err := template.New("FileValidator").Parse(structValidatorTpl)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := os.WriteFile(s.Path+"/"+strings.ToLower(s.Name)+"_validator.go", []byte(code), 0644); err != nil {
-		return err
+	code := new(bytes.Buffer)
+	if err := tmpl.Execute(code, validator); err != nil {
+		return nil, err
 	}
 
-	return nil
+	formatted, err := formatCode(code.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(w, formatted); err != nil {
+		return nil, err
+	}
+
+	return validator, nil
 }
 
-func (s *StructInfo) GenerateFilePackageDefinition() error {
-	fmt.Println("Generating package definitions code")
+// structChecks returns the checks of all the fields of the struct, adding
+// the imports, regexps and helpers they use to vc.
+func (vc *validatorCode) structChecks() (string, error) {
+	checks := ""
+	for _, fieldInfo := range vc.FieldsInfo {
+		if fieldInfo.Skipped() {
+			continue
+		}
 
-	code, err := s.Generate()
+		fieldChecks, err := vc.fieldChecks(fieldInfo)
+		if err != nil {
+			return "", err
+		}
+
+		checks += fieldChecks
+	}
+
+	// The checks open the function body unless errs is declared first.
+	if vc.FailFast || vc.ValidateInto {
+		checks = strings.TrimPrefix(checks, "\n")
+	}
+
+	if !vc.FailFast && vc.JoinErrors {
+		vc.addImport("errors")
+	}
+
+	if vc.NilGuard() != "" && !vc.StructuredErrors {
+		vc.addImport("fmt")
+		vc.useSentinel()
+	}
+
+	if vc.DeclaresErrorType() {
+		vc.useSentinel()
+	}
+
+	return checks, nil
+}
+
+// formatCode returns the generated code in the gofmt canonical format.
+func formatCode(code []byte) (string, error) {
+	formatted, err := format.Source(code)
 	if err != nil {
-		return err
+		return "", fmt.Errorf("formatting generated code: %w", err)
 	}
 
-	if err := os.WriteFile(s.Path+"/validators.go", []byte(code), 0644); err != nil {
-		return err
+	return string(formatted), nil
+}
+
+// checkTarget is the value that the validations are applied to.
+type checkTarget struct {
+	operand   string   // Expression that evaluates to the value.
+	fieldName string   // Name used in the error messages.
+	fieldType string   // Type of the value.
+	msgArgs   []string // Arguments referenced by the fieldName formatting verbs.
+	message   string   // Custom error message replacing the generated ones.
+	embedded  bool     // Embedded struct, whose errors are reported unprefixed.
+	namedType string   // Named type of the value, before resolving it.
+}
+
+func (vc *validatorCode) fieldChecks(fieldInfo FieldInfo) (string, error) {
+	target := checkTarget{
+		operand:   "obj." + fieldInfo.Name,
+		fieldName: fieldInfo.DisplayName(),
+		fieldType: fieldInfo.Type,
+		embedded:  fieldInfo.Embedded,
 	}
 
-	return nil
+	if vc.PrefixStructName {
+		target.fieldName = vc.Name + "." + target.fieldName
+	}
+
+	validations := fieldInfo.Validations
+	if last := len(validations) - 1; last >= 0 && strings.HasPrefix(validations[last], "msg=") {
+		target.message = strings.TrimPrefix(validations[last], "msg=")
+		validations = validations[:last]
+	}
+
+	return vc.valueChecks(target, validations)
 }
 
-func (s *StructInfo) Generate() (string, error) {
-	tmpl, err := template.New("PkgDef").Parse(packageDefinitionTpl)
+func (vc *validatorCode) valueChecks(target checkTarget, fieldValidations []string) (string, error) {
+	target = vc.underlying(target)
+
+	if len(fieldValidations) > 0 && fieldValidations[0] == "omitempty" {
+		return vc.omitEmptyChecks(target, fieldValidations[1:])
+	}
+
+	elemType, isPointer := strings.CutPrefix(target.fieldType, "*")
+	if isPointer {
+		return vc.pointerChecks(target, elemType, fieldValidations)
+	}
+
+	fieldValidations, elemValidations, dive := splitDive(fieldValidations)
+
+	// Slices of structs with a validator are validated without dive.
+	if elemType, isSlice := strings.CutPrefix(target.fieldType, "[]"); isSlice && !dive {
+		dive = vc.NestedStructs[strings.TrimPrefix(elemType, "*")]
+	}
+
+	checks := ""
+	if vc.NestedStructs[target.fieldType] {
+		checks += vc.nestedCheck(target)
+		fieldValidations = withoutValidation(fieldValidations, "required")
+	}
+
+	fieldChecks, err := vc.checks(target, fieldValidations)
+	checks += fieldChecks
+
+	if dive {
+		diveChecks, diveErr := vc.diveChecks(target, elemValidations)
+		checks += diveChecks
+		err = errors.Join(err, diveErr)
+	}
 	if err != nil {
 		return "", err
 	}
 
-	code := new(bytes.Buffer)
-	if err := tmpl.Execute(code, s); err != nil {
+	return checks, nil
+}
+
+// omitEmptyChecks only applies the validations when the value is not empty.
+func (vc *validatorCode) omitEmptyChecks(target checkTarget, fieldValidations []string) (string, error) {
+	checks, err := vc.valueChecks(target, fieldValidations)
+	if err != nil || checks == "" {
+		return checks, err
+	}
+
+	// Pointers are already only validated when they are not nil.
+	if strings.HasPrefix(target.fieldType, "*") {
+		return checks, nil
+	}
+
+	_, notEmpty, err := emptyConditions(target.operand, target.fieldType)
+	if err != nil {
+		return "", fmt.Errorf("field %s: %w", target.fieldName, err)
+	}
+
+	return blockChecks("if "+notEmpty, checks), nil
+}
+
+// pointerChecks applies the required and conditional validations to the
+// pointer, so that they fail when it is nil, and the remaining validations to
+// the pointed value.
+func (vc *validatorCode) pointerChecks(target checkTarget, elemType string, fieldValidations []string) (string, error) {
+	fieldValidations, elemValidations, dive := splitDive(fieldValidations)
+
+	var pointerValidations, valueValidations []string
+	for _, fieldValidation := range fieldValidations {
+		validation, _, _ := strings.Cut(fieldValidation, "=")
+		if validation == "required" || conditionalValidations[validation] {
+			pointerValidations = append(pointerValidations, fieldValidation)
+		} else {
+			valueValidations = append(valueValidations, fieldValidation)
+		}
+	}
+	fieldValidations = valueValidations
+
+	checks, err := vc.checks(target, pointerValidations)
+
+	if dive {
+		fieldValidations = append(append(fieldValidations[:len(fieldValidations):len(fieldValidations)], "dive"), elemValidations...)
+	}
+
+	elem := target
+	elem.operand = "*" + target.operand
+	elem.fieldType = elemType
+
+	elemChecks, elemErr := vc.valueChecks(elem, fieldValidations)
+	if err := errors.Join(err, elemErr); err != nil {
 		return "", err
 	}
 
-	return code.String(), nil
+	if elemChecks != "" {
+		checks += blockChecks("if "+target.operand+" != nil", elemChecks)
+	}
+
+	return checks, nil
+}
+
+// diveChecks applies the validations to every element of a slice.
+func (vc *validatorCode) diveChecks(target checkTarget, elemValidations []string) (string, error) {
+	if keyType, valueType, isMap := mapTypes(target.fieldType); isMap {
+		return vc.mapDiveChecks(target, keyType, valueType, elemValidations)
+	}
+
+	elemType, isSlice := strings.CutPrefix(target.fieldType, "[]")
+	if !isSlice {
+		return "", fmt.Errorf("field %s: dive on type %s is unsupported", target.fieldName, target.fieldType)
+	}
+
+	index := indexVar(len(target.msgArgs))
+	collection := target.operand
+	if strings.HasPrefix(collection, "*") {
+		collection = "(" + collection + ")"
+	}
+
+	elem := checkTarget{
+		operand:   collection + "[" + index + "]",
+		fieldName: target.fieldName + "[%d]",
+		fieldType: elemType,
+		msgArgs:   append(append([]string{}, target.msgArgs...), index),
+	}
+
+	elemChecks, err := vc.valueChecks(elem, elemValidations)
+	if err != nil || elemChecks == "" {
+		return "", err
+	}
+
+	return blockChecks("for "+index+" := range "+target.operand, elemChecks), nil
+}
+
+func (vc *validatorCode) checks(target checkTarget, fieldValidations []string) (string, error) {
+	lower, upper, fuseRange, fused := "", "", false, false
+	if vc.FuseRanges {
+		lower, upper, fuseRange = rangeValidations(fieldValidations)
+	}
+
+	// Every validation is checked, reporting all the incompatible ones.
+	checks := ""
+	var errs []error
+	for _, fieldValidation := range fieldValidations {
+		// The range check takes the place of the first of its bounds.
+		if fuseRange && (fieldValidation == lower || fieldValidation == upper) {
+			if !fused {
+				rangeCheck, err := vc.rangeCheck(target, "range", lower, upper)
+				if err != nil {
+					errs = append(errs, err)
+				}
+
+				checks += rangeCheck
+				fused = true
+			}
+
+			continue
+		}
+
+		validation, args, _ := strings.Cut(fieldValidation, "=")
+		if validation == "between" {
+			if _, err := fieldTestElements(target.operand, target.fieldName, fieldValidation, target.fieldType); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			lowerBound, upperBound, _ := strings.Cut(args, ",")
+			rangeCheck, err := vc.rangeCheck(target, validation, "gte="+strings.TrimSpace(lowerBound), "lte="+strings.TrimSpace(upperBound))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			checks += rangeCheck
+			continue
+		}
+
+		if conditionalValidations[validation] {
+			conditionalCheck, err := vc.conditionalCheck(target, validation, args)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("field %s: %w", target.fieldName, err))
+				continue
+			}
+
+			checks += conditionalCheck
+			continue
+		}
+
+		referenced, err := vc.fieldReference(target, fieldValidation)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", target.fieldName, err))
+			continue
+		}
+
+		testElements, err := fieldTestElements(target.operand, target.fieldName, fieldValidation, target.fieldType)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if referenced != "" {
+			testElements.roperand = strings.Replace(testElements.roperand, "obj."+args, referenced, 1)
+		}
+
+		checks += vc.check(target, validation, testElements)
+	}
+	if len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+
+	return checks, nil
+}
+
+// rangeValidations returns the gte and lte validations of the field, and
+// whether both are present.
+func rangeValidations(fieldValidations []string) (string, string, bool) {
+	var lower, upper string
+	for _, fieldValidation := range fieldValidations {
+		if lower == "" && strings.HasPrefix(fieldValidation, "gte=") {
+			lower = fieldValidation
+		}
+		if upper == "" && strings.HasPrefix(fieldValidation, "lte=") {
+			upper = fieldValidation
+		}
+	}
+
+	return lower, upper, lower != "" && upper != ""
+}
+
+// rangeCheck emits a single check for the lower and upper bounds.
+func (vc *validatorCode) rangeCheck(target checkTarget, tag, lower, upper string) (string, error) {
+	lowerElements, err := fieldTestElements(target.operand, target.fieldName, lower, target.fieldType)
+	if err != nil {
+		return "", err
+	}
+
+	upperElements, err := fieldTestElements(target.operand, target.fieldName, upper, target.fieldType)
+	if err != nil {
+		return "", err
+	}
+
+	lowerElements = vc.runeCounted(target, lowerElements)
+	upperElements = vc.runeCounted(target, upperElements)
+
+	return vc.check(target, tag, rangeTestElements(lowerElements, upperElements, target.fieldName, target.fieldType, strings.TrimPrefix(lower, "gte="), strings.TrimPrefix(upper, "lte="))), nil
+}
+
+// rangeTestElements combines the tests of the lower and upper bounds into a
+// test failing when the value is out of either of them.
+func rangeTestElements(lowerElements, upperElements FieldTestElements, fieldName, fieldType, lower, upper string) FieldTestElements {
+	name := fieldName
+	if typeKind(fieldType) == "string" {
+		name += " length"
+	}
+
+	return FieldTestElements{
+		condition:    lowerElements.Condition() + " || " + upperElements.Condition(),
+		errorMessage: fmt.Sprintf("%s must be between %s and %s", name, lower, upper),
+		imports:      append(lowerElements.imports[:len(lowerElements.imports):len(lowerElements.imports)], upperElements.imports...),
+	}
+}
+
+// betweenTestElements builds the test of the between validation, whose
+// target holds the lower and upper bounds separated by a comma.
+func betweenTestElements(operand, fieldName, target, fieldType string) (FieldTestElements, error) {
+	lower, upper, found := strings.Cut(target, ",")
+	lower, upper = strings.TrimSpace(lower), strings.TrimSpace(upper)
+	if !found || lower == "" || upper == "" {
+		return FieldTestElements{}, fmt.Errorf("validation between for field %s requires the min,max bounds instead of %q", fieldName, target)
+	}
+
+	if kind := typeKind(fieldType); kind != "number" && kind != "string" {
+		return FieldTestElements{}, fmt.Errorf("unsupported validation between=%s for field %s of type %s", target, fieldName, fieldType)
+	}
+
+	lowerElements, err := fieldTestElements(operand, fieldName, "gte="+lower, fieldType)
+	if err != nil {
+		return FieldTestElements{}, err
+	}
+
+	upperElements, err := fieldTestElements(operand, fieldName, "lte="+upper, fieldType)
+	if err != nil {
+		return FieldTestElements{}, err
+	}
+
+	return rangeTestElements(lowerElements, upperElements, fieldName, fieldType, lower, upper), nil
+}
+
+// runeCounted replaces the byte length by the rune count when CountRunes is
+// set.
+func (vc *validatorCode) runeCounted(target checkTarget, testElements FieldTestElements) FieldTestElements {
+	if vc.CountRunes && typeKind(target.fieldType) == "string" {
+		testElements.loperand = countRunes(testElements.loperand)
+		testElements.roperand = countRunes(testElements.roperand)
+		if strings.HasPrefix(testElements.loperand, "utf8.") {
+			testElements.imports = append(testElements.imports, "unicode/utf8")
+		}
+	}
+
+	return testElements
+}
+
+// check emits the code that reports an error when the test fails.
+func (vc *validatorCode) check(target checkTarget, tag string, testElements FieldTestElements) string {
+	testElements = vc.runeCounted(target, testElements)
+
+	// Custom messages are used verbatim, without the loop variables.
+	messageArgs := target.msgArgs
+	if target.message != "" {
+		testElements.errorMessage = strings.Replace(target.message, "%", "%%", -1)
+		messageArgs = nil
+	} else if vc.IncludeValue && !strings.HasPrefix(tag, "required") {
+		// Required values are always empty when the validation fails.
+		testElements.errorMessage += " (got " + valueVerb(target.fieldType) + ")"
+		messageArgs = append(append([]string{}, target.msgArgs...), target.operand)
+	}
+
+	var validationError string
+	if vc.StructuredErrors {
+		validationError = vc.structuredError(target, tag, testElements.errorMessage, messageArgs)
+	} else {
+		vc.addImport("fmt")
+		errorArgs := append([]string{strconv.Quote("%w: " + testElements.errorMessage), vc.useSentinel()}, messageArgs...)
+		validationError = "fmt.Errorf(" + strings.Join(errorArgs, ", ") + ")"
+	}
+
+	for _, importPath := range testElements.imports {
+		// The regexp package is imported by the regexp declaration.
+		if importPath == "regexp" && testElements.regexp != "" {
+			continue
+		}
+
+		vc.addImport(importPath)
+	}
+
+	if testElements.helper != "" {
+		vc.addHelper(helperFuncs[testElements.helper])
+	}
+
+	condition := testElements.Condition()
+	if testElements.regexp != "" {
+		regexpName := vc.addRegexp(testElements.regexpName, testElements.regexp)
+		condition = strings.Replace(condition, "{{.Regexp}}", regexpName, -1)
+	}
+
+	return fmt.Sprintf(
+		`
+	if %s {
+		%s
+	}
+`, condition, vc.report(validationError))
+}
+
+// valueVerb returns the formatting verb of a value of the type in the error
+// messages.
+func valueVerb(fieldType string) string {
+	if typeKind(fieldType) == "string" {
+		return "%q"
+	}
+
+	return "%v"
+}
+
+// report returns the statement that records the validation error.
+func (vc *validatorCode) report(validationError string) string {
+	if vc.FailFast && vc.JoinErrors {
+		return "return " + validationError
+	}
+
+	if vc.FailFast && vc.ValidateInto {
+		return "return append(errs, " + validationError + ")"
+	}
+
+	if vc.FailFast {
+		return "return []error{" + validationError + "}"
+	}
+
+	return "errs = append(errs, " + validationError + ")"
+}
+
+// structuredError builds a ValidationError literal. The field name and the
+// message are only formatted when they reference loop variables.
+func (vc *validatorCode) structuredError(target checkTarget, tag, errorMessage string, messageArgs []string) string {
+	field := strconv.Quote(target.fieldName)
+	if len(target.msgArgs) > 0 {
+		vc.addImport("fmt")
+		field = fmt.Sprintf("fmt.Sprintf(%s, %s)", field, strings.Join(target.msgArgs, ", "))
+	}
+
+	message := strconv.Quote(strings.Replace(errorMessage, "%%", "%", -1))
+	if len(messageArgs) > 0 {
+		vc.addImport("fmt")
+		message = fmt.Sprintf("fmt.Sprintf(%s, %s)", strconv.Quote(errorMessage), strings.Join(messageArgs, ", "))
+	}
+
+	return fmt.Sprintf("&ValidationError{Field: %s, Tag: %s, Message: %s}", field, strconv.Quote(tag), message)
+}
+
+func countRunes(operand string) string {
+	if !strings.HasPrefix(operand, "len(") {
+		return operand
+	}
+
+	return "utf8.RuneCountInString(" + strings.TrimPrefix(operand, "len(")
+}
+
+// conditionalCheck applies the required validation only when the condition
+// over other fields of the struct holds.
+func (vc *validatorCode) conditionalCheck(target checkTarget, validation, args string) (string, error) {
+	var condition, description string
+	var err error
+
+	switch validation {
+	case "required_if":
+		condition, description, err = vc.fieldValuesCondition(args)
+	case "required_with":
+		condition, description, err = vc.fieldsEmptinessCondition(args, false, " || ")
+	case "required_without":
+		condition, description, err = vc.fieldsEmptinessCondition(args, true, " || ")
+	case "required_with_all":
+		condition, description, err = vc.fieldsEmptinessCondition(args, false, " && ")
+	case "required_without_all":
+		condition, description, err = vc.fieldsEmptinessCondition(args, true, " && ")
+	case "excluded_if":
+		condition, description, err = vc.fieldValuesCondition(args)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var testElements FieldTestElements
+	if validation == "excluded_if" {
+		_, notEmpty, err := emptyConditions(target.operand, target.fieldType)
+		if err != nil {
+			return "", err
+		}
+		testElements = FieldTestElements{condition: notEmpty, errorMessage: target.fieldName + " must be empty"}
+	} else if testElements, err = fieldTestElements(target.operand, target.fieldName, "required", target.fieldType); err != nil {
+		return "", err
+	}
+	testElements.errorMessage += " when " + description
+
+	return blockChecks("if "+condition, vc.check(target, validation, testElements)), nil
+}
+
+// fieldValuesCondition builds a condition that holds when every field of the
+// space separated field/value pairs has the given value.
+func (vc *validatorCode) fieldValuesCondition(args string) (string, string, error) {
+	pairs := strings.Fields(args)
+	if len(pairs) == 0 || len(pairs)%2 != 0 {
+		return "", "", fmt.Errorf("invalid field and value pairs %q", args)
+	}
+
+	var conditions, descriptions []string
+	for i := 0; i < len(pairs); i += 2 {
+		fieldName, value := pairs[i], pairs[i+1]
+
+		referenced, err := vc.referencedField(fieldName)
+		if err != nil {
+			return "", "", err
+		}
+
+		literal, err := valueLiteral(value, referenced.fieldType)
+		if err != nil {
+			return "", "", err
+		}
+
+		conditions = append(conditions, referenced.operand+" == "+literal)
+		descriptions = append(descriptions, fieldName+" is "+strings.Replace(value, "%", "%%", -1))
+	}
+
+	return strings.Join(conditions, " && "), strings.Join(descriptions, " and "), nil
+}
+
+// fieldsEmptinessCondition builds a condition that holds when the space
+// separated fields are empty, or not empty, combined with the logical operator.
+func (vc *validatorCode) fieldsEmptinessCondition(args string, empty bool, operator string) (string, string, error) {
+	fieldNames := strings.Fields(args)
+	if len(fieldNames) == 0 {
+		return "", "", fmt.Errorf("missing fields in %q", args)
+	}
+
+	var conditions []string
+	for _, fieldName := range fieldNames {
+		referenced, err := vc.referencedField(fieldName)
+		if err != nil {
+			return "", "", err
+		}
+
+		emptyCondition, notEmptyCondition, err := emptyConditions(referenced.operand, referenced.fieldType)
+		if err != nil {
+			return "", "", err
+		}
+
+		if empty {
+			conditions = append(conditions, emptyCondition)
+		} else {
+			conditions = append(conditions, notEmptyCondition)
+		}
+	}
+
+	conjunction, verb, state := " or ", " is ", "present"
+	if operator == " && " {
+		conjunction = " and "
+	}
+	if len(fieldNames) > 1 && conjunction == " and " {
+		verb = " are "
+	}
+	if empty {
+		state = "empty"
+	}
+
+	return strings.Join(conditions, operator), strings.Join(fieldNames, conjunction) + verb + state, nil
+}
+
+// valueLiteral returns the Go literal of the value for the given type.
+func valueLiteral(value, fieldType string) (string, error) {
+	switch typeKind(fieldType) {
+	case "string":
+		return strconv.Quote(value), nil
+	case "bool":
+		if value != "true" && value != "false" {
+			return "", fmt.Errorf("invalid bool value %s", value)
+		}
+		return value, nil
+	case "number":
+		return value, nil
+	}
+
+	return "", fmt.Errorf("comparing values of type %s is unsupported", fieldType)
+}
+
+// fieldReference ensures that cross-field validations reference a field of
+// the struct with the same underlying type, returning the expression that
+// evaluates to it. Values of different named types are converted to the type
+// of the target.
+func (vc *validatorCode) fieldReference(target checkTarget, fieldValidation string) (string, error) {
+	validation, fieldName, _ := strings.Cut(fieldValidation, "=")
+	// A missing field is reported as any other missing argument.
+	if !crossFieldValidations[validation] || fieldName == "" {
+		return "", nil
+	}
+
+	field, ok := vc.Field(fieldName)
+	if !ok {
+		return "", fmt.Errorf("validation %s references unknown field %s", fieldValidation, fieldName)
+	}
+
+	referenced := vc.underlying(checkTarget{operand: "obj." + fieldName, fieldType: field.Type})
+	if referenced.fieldType != target.fieldType {
+		return "", fmt.Errorf("validation %s references field %s of type %s instead of %s", fieldValidation, fieldName, referenced.fieldType, target.fieldType)
+	}
+
+	// Named strings are already converted on both sides.
+	targetType := target.fieldType
+	if target.namedType != "" {
+		targetType = target.namedType
+	}
+	if target.fieldType != "string" && field.Type != targetType {
+		return targetType + "(obj." + fieldName + ")", nil
+	}
+
+	return referenced.operand, nil
+}
+
+// referencedField returns the field of the struct referenced by a condition,
+// with its named type resolved.
+func (vc *validatorCode) referencedField(fieldName string) (checkTarget, error) {
+	field, ok := vc.Field(fieldName)
+	if !ok {
+		return checkTarget{}, fmt.Errorf("condition references unknown field %s", fieldName)
+	}
+
+	return vc.underlying(checkTarget{operand: "obj." + fieldName, fieldType: field.Type}), nil
+}
+
+// underlying resolves a named type to its underlying type. Values of named
+// string types are converted, so they can be passed to the strings functions.
+func (vc *validatorCode) underlying(target checkTarget) checkTarget {
+	underlyingType, ok := vc.NamedTypes[target.fieldType]
+	if !ok {
+		return target
+	}
+
+	if underlyingType == "string" {
+		target.operand = "string(" + target.operand + ")"
+	}
+	if target.namedType == "" {
+		target.namedType = target.fieldType
+	}
+	target.fieldType = underlyingType
+
+	return vc.underlying(target)
+}
+
+// nestedCheck validates a struct field with its own validator, prefixing
+// the returned errors with the field name. The errors of embedded structs are
+// reported as they are, like their promoted fields.
+func (vc *validatorCode) nestedCheck(target checkTarget) string {
+	// Pointer validators receive the field address, value validators a copy.
+	// Methods are called on the field, which Go addresses or dereferences.
+	argument := target.operand
+	if vc.AsMethod {
+		argument = strings.TrimPrefix(target.operand, "*")
+	} else if !vc.ByValue {
+		var isDeref bool
+		if argument, isDeref = strings.CutPrefix(target.operand, "*"); !isDeref {
+			argument = "&" + target.operand
+		}
+	}
+	call := vc.ValidatorCall(target.fieldType, argument)
+
+	wrap := func(err string) string {
+		if target.embedded {
+			return err
+		}
+
+		vc.addImport("fmt")
+		errorArgs := append([]string{strconv.Quote(target.fieldName + ": %w")}, target.msgArgs...)
+		return "fmt.Errorf(" + strings.Join(append(errorArgs, err), ", ") + ")"
+	}
+
+	if vc.JoinErrors {
+		return fmt.Sprintf(
+			`
+	if err := %s; err != nil {
+		%s
+	}
+`, call, vc.report(wrap("err")))
+	}
+
+	if vc.FailFast {
+		return fmt.Sprintf(
+			`
+	if nestedErrs := %s; len(nestedErrs) > 0 {
+		%s
+	}
+`, call, vc.report(wrap("nestedErrs[0]")))
+	}
+
+	if target.embedded {
+		return fmt.Sprintf(
+			`
+	errs = append(errs, %s...)
+`, call)
+	}
+
+	return fmt.Sprintf(
+		`
+	for _, err := range %s {
+		errs = append(errs, %s)
+	}
+`, call, wrap("err"))
+}
+
+// emptyConditions returns the expressions that are true when the value is,
+// and is not, the zero value of its type.
+func emptyConditions(operand, fieldType string) (string, string, error) {
+	switch typeKind(fieldType) {
+	case "string":
+		return operand + ` == ""`, operand + ` != ""`, nil
+	case "number":
+		return operand + " == 0", operand + " != 0", nil
+	case "bool":
+		return "!" + operand, operand, nil
+	case "slice", "map":
+		return "len(" + operand + ") == 0", "len(" + operand + ") != 0", nil
+	case "pointer", "interface":
+		return operand + " == nil", operand + " != nil", nil
+	case "time.Time":
+		return operand + ".IsZero()", "!" + operand + ".IsZero()", nil
+	}
+
+	return "", "", fmt.Errorf("empty value of type %s is unsupported", fieldType)
+}
+
+// splitDive separates the validations applied to a collection from the ones
+// applied to its elements, which follow the dive keyword.
+func splitDive(fieldValidations []string) ([]string, []string, bool) {
+	for i, fieldValidation := range fieldValidations {
+		if fieldValidation == "dive" {
+			return fieldValidations[:i], fieldValidations[i+1:], true
+		}
+
+		// The map keys validations also dive into the map.
+		if fieldValidation == "keys" {
+			return fieldValidations[:i], fieldValidations[i:], true
+		}
+	}
+
+	return fieldValidations, nil, false
+}
+
+// indexVar names the loop variable of the given dive depth.
+func indexVar(depth int) string {
+	return string(rune('i' + depth))
+}
+
+// mapDiveChecks validates each key and value of a map, reporting its key. The
+// keys validations are enclosed by keys and endkeys. Struct values are copied,
+// since map elements are not addressable.
+func (vc *validatorCode) mapDiveChecks(target checkTarget, keyType, valueType string, elemValidations []string) (string, error) {
+	keyValidations, elemValidations, err := splitKeys(elemValidations)
+	if err != nil {
+		return "", fmt.Errorf("field %s: %w", target.fieldName, err)
+	}
+
+	key := "k"
+	if depth := len(target.msgArgs); depth > 0 {
+		key += strconv.Itoa(depth)
+	}
+
+	collection := target.operand
+	if strings.HasPrefix(collection, "*") {
+		collection = "(" + collection + ")"
+	}
+
+	keyVerb := "%v"
+	if keyType == "string" {
+		keyVerb = "%s"
+	}
+
+	elem := checkTarget{
+		operand:   collection + "[" + key + "]",
+		fieldName: target.fieldName + "[" + keyVerb + "]",
+		fieldType: valueType,
+		msgArgs:   append(append([]string{}, target.msgArgs...), key),
+	}
+
+	statement := "for " + key + " := range " + target.operand
+	if vc.NestedStructs[valueType] {
+		elem.operand = "value"
+		statement = "for " + key + ", value := range " + target.operand
+	}
+
+	keyChecks, err := vc.valueChecks(checkTarget{
+		operand:   key,
+		fieldName: elem.fieldName + " key",
+		fieldType: keyType,
+		msgArgs:   elem.msgArgs,
+	}, keyValidations)
+	if err != nil {
+		return "", err
+	}
+
+	elemChecks, err := vc.valueChecks(elem, elemValidations)
+	if err != nil || keyChecks+elemChecks == "" {
+		return "", err
+	}
+
+	return blockChecks(statement, keyChecks+elemChecks), nil
+}
+
+// splitKeys separates the validations of the map keys, between keys and
+// endkeys, from the ones of the values.
+func splitKeys(elemValidations []string) ([]string, []string, error) {
+	if len(elemValidations) == 0 || elemValidations[0] != "keys" {
+		return nil, elemValidations, nil
+	}
+
+	for i, elemValidation := range elemValidations {
+		if elemValidation == "endkeys" {
+			return elemValidations[1:i], elemValidations[i+1:], nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("keys without endkeys")
+}
+
+// mapTypes returns the key and value types of a map type.
+func mapTypes(fieldType string) (string, string, bool) {
+	rest, isMap := strings.CutPrefix(fieldType, "map[")
+	if !isMap {
+		return "", "", false
+	}
+
+	depth := 1
+	for i, c := range rest {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return rest[:i], rest[i+1:], true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+// blockChecks nests the checks inside the block of an if or for statement.
+func blockChecks(statement, checks string) string {
+	return fmt.Sprintf("\n\t%s {\n%s\t}\n", statement, indent(strings.TrimPrefix(checks, "\n")))
+}
+
+func indent(code string) string {
+	lines := strings.SplitAfter(code, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = "\t" + line
+		}
+	}
+
+	return strings.Join(lines, "")
+}
+
+func hasValidation(fieldValidations []string, validation string) bool {
+	for _, fieldValidation := range fieldValidations {
+		if fieldValidation == validation {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withoutValidation(fieldValidations []string, validation string) []string {
+	var filtered []string
+	for _, fieldValidation := range fieldValidations {
+		if fieldValidation != validation {
+			filtered = append(filtered, fieldValidation)
+		}
+	}
+
+	return filtered
+}
+
+// useSentinel returns the sentinel error, importing its package when it is
+// declared by another one.
+func (vc *validatorCode) useSentinel() string {
+	if vc.ErrSentinelImport != "" {
+		vc.addImport(vc.ErrSentinelImport)
+	}
+
+	return vc.Sentinel()
+}
+
+func (vc *validatorCode) addImport(importPath string) {
+	for _, current := range vc.Imports {
+		if current == importPath {
+			return
+		}
+	}
+
+	vc.Imports = append(vc.Imports, importPath)
+}
+
+// addHelper declares the helper function once in the package.
+func (vc *validatorCode) addHelper(code string) {
+	if vc.DeclaredHelpers[code] {
+		return
+	}
+
+	for _, current := range vc.Helpers {
+		if current == code {
+			return
+		}
+	}
+
+	vc.Helpers = append(vc.Helpers, code)
+}
+
+// addRegexp declares the regexp once in the package and returns the variable
+// name to be used by the checks. Patterns without a predefined name get a
+// generated one, not used by the other files of the package.
+func (vc *validatorCode) addRegexp(name, pattern string) string {
+	if declared, ok := vc.DeclaredRegexps[pattern]; ok {
+		return declared
+	}
+
+	for _, current := range vc.Regexps {
+		if current.Pattern == pattern {
+			return current.Name
+		}
+	}
+
+	for name == "" {
+		name = fmt.Sprintf("fieldPattern%d", vc.patternCount)
+		vc.patternCount++
+		for _, declared := range vc.DeclaredRegexps {
+			if declared == name {
+				name = ""
+			}
+		}
+	}
+
+	vc.addImport("regexp")
+	vc.Regexps = append(vc.Regexps, regexpVar{Name: name, Pattern: pattern})
+
+	return name
+}
+
+// GetFieldTestElements returns the test of a field validation. The error
+// message uses displayName, or fieldName when it is empty.
+func GetFieldTestElements(fieldName, displayName, fieldValidation, fieldType string) (FieldTestElements, error) {
+	if displayName == "" {
+		displayName = fieldName
+	}
+
+	return fieldTestElements("obj."+fieldName, displayName, fieldValidation, fieldType)
+}
+
+// FieldTestElementsFor returns the tests of all the validations of the field,
+// in the order of its tag. A custom message replaces the message of every
+// test. The validations after dive, and the ones depending on the values of
+// other fields, have no single test and are left out or rejected. The
+// elements are read with the Condition, Operands, ErrorMessage, Imports,
+// Regexp and Helper methods.
+func FieldTestElementsFor(field FieldInfo) ([]FieldTestElements, error) {
+	if field.Skipped() {
+		return nil, nil
+	}
+
+	validations := field.Validations
+	message := ""
+	if last := len(validations) - 1; last >= 0 && strings.HasPrefix(validations[last], "msg=") {
+		message = strings.TrimPrefix(validations[last], "msg=")
+		validations = validations[:last]
+	}
+
+	if len(validations) > 0 && validations[0] == "omitempty" {
+		validations = validations[1:]
+	}
+
+	var tests []FieldTestElements
+	regexps := &validatorCode{StructInfo: &StructInfo{}}
+	for _, fieldValidation := range validations {
+		validation, _, _ := strings.Cut(fieldValidation, "=")
+		if validation == "dive" || validation == "keys" {
+			break
+		}
+
+		if conditionalValidations[validation] {
+			return nil, fmt.Errorf("validation %s for field %s depends on other fields", fieldValidation, field.Name)
+		}
+
+		testElements, err := GetFieldTestElements(field.Name, field.DisplayName(), fieldValidation, field.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if message != "" {
+			testElements.errorMessage = strings.Replace(message, "%", "%%", -1)
+		}
+
+		// Patterns without a predefined name are named as in the validator.
+		if testElements.regexp != "" {
+			testElements.regexpName = regexps.addRegexp(testElements.regexpName, testElements.regexp)
+			testElements.condition = strings.Replace(testElements.condition, "{{.Regexp}}", testElements.regexpName, -1)
+		}
+
+		tests = append(tests, testElements)
+	}
+
+	return tests, nil
+}
+
+// fieldTestElements builds the test elements applying the validation to the
+// operand expression, using fieldName in the error message.
+func fieldTestElements(operand, fieldName, fieldValidation, fieldType string) (FieldTestElements, error) {
+	ifCode := map[string]FieldTestElements{
+		"required,string":         {loperand: "{{.Name}}", operator: "==", roperand: `""`, errorMessage: "{{.Name}} required"},
+		"required,number":         {loperand: "{{.Name}}", operator: "==", roperand: `0`, errorMessage: "{{.Name}} required"},
+		"required,bool":           {loperand: "{{.Name}}", operator: "!=", roperand: `true`, errorMessage: "{{.Name}} required"},
+		"required,pointer":        {loperand: "{{.Name}}", operator: "==", roperand: `nil`, errorMessage: "{{.Name}} required"},
+		"required,interface":      {loperand: "{{.Name}}", operator: "==", roperand: `nil`, errorMessage: "{{.Name}} required"},
+		"required,map":            {loperand: "len({{.Name}})", operator: "==", roperand: `0`, errorMessage: "{{.Name}} required"},
+		"required,slice":          {loperand: "len({{.Name}})", operator: "==", roperand: `0`, errorMessage: "{{.Name}} required"},
+		"required,time.Time":      {condition: "{{.Name}}.IsZero()", errorMessage: "{{.Name}} required"},
+		"required_trimmed,string": {condition: `strings.TrimSpace({{.Name}}) == ""`, errorMessage: "{{.Name}} required", imports: []string{"strings"}},
+		"eq,string":               {loperand: "{{.Name}}", operator: "!=", roperand: `{{.QuotedTarget}}`, errorMessage: "{{.Name}} must be == {{.Target}}"},
+		"eq,number":               {loperand: "{{.Name}}", operator: "!=", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must be == {{.Target}}"},
+		"ne,string":               {loperand: "{{.Name}}", operator: "==", roperand: `{{.QuotedTarget}}`, errorMessage: "{{.Name}} must be != {{.Target}}"},
+		"ne,number":               {loperand: "{{.Name}}", operator: "==", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must be != {{.Target}}"},
+		"eq,bool":                 {loperand: "{{.Name}}", operator: "!=", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must be == {{.Target}}"},
+		"ne,bool":                 {loperand: "{{.Name}}", operator: "==", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must be != {{.Target}}"},
+		"gte,number":              {loperand: "{{.Name}}", operator: "<", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must be >= {{.Target}}"},
+		"lte,number":              {loperand: "{{.Name}}", operator: ">", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must be <= {{.Target}}"},
+		"gt,number":               {loperand: "{{.Name}}", operator: "<=", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must be > {{.Target}}"},
+		"lt,number":               {loperand: "{{.Name}}", operator: ">=", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must be < {{.Target}}"},
+		"gte,string":              {loperand: "len({{.Name}})", operator: "<", roperand: `{{.Target}}`, errorMessage: "length {{.Name}} must be >= {{.Target}}"},
+		"lte,string":              {loperand: "len({{.Name}})", operator: ">", roperand: `{{.Target}}`, errorMessage: "length {{.Name}} must be <= {{.Target}}"},
+		"gt,string":               {loperand: "len({{.Name}})", operator: "<=", roperand: `{{.Target}}`, errorMessage: "length {{.Name}} must be > {{.Target}}"},
+		"lt,string":               {loperand: "len({{.Name}})", operator: ">=", roperand: `{{.Target}}`, errorMessage: "length {{.Name}} must be < {{.Target}}"},
+		"min,number":              {loperand: "{{.Name}}", operator: "<", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must be >= {{.Target}}"},
+		"max,number":              {loperand: "{{.Name}}", operator: ">", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must be <= {{.Target}}"},
+		"min,string":              {loperand: "len({{.Name}})", operator: "<", roperand: `{{.Target}}`, errorMessage: "length {{.Name}} must be >= {{.Target}}"},
+		"max,string":              {loperand: "len({{.Name}})", operator: ">", roperand: `{{.Target}}`, errorMessage: "length {{.Name}} must be <= {{.Target}}"},
+		"len,string":              {loperand: "len({{.Name}})", operator: "!=", roperand: `{{.Target}}`, errorMessage: "length {{.Name}} must be == {{.Target}}"},
+		"min,[]byte":              {loperand: "len({{.Name}})", operator: "<", roperand: `{{.Target}}`, errorMessage: "length {{.Name}} must be >= {{.Target}}"},
+		"max,[]byte":              {loperand: "len({{.Name}})", operator: ">", roperand: `{{.Target}}`, errorMessage: "length {{.Name}} must be <= {{.Target}}"},
+		"len,[]byte":              {loperand: "len({{.Name}})", operator: "!=", roperand: `{{.Target}}`, errorMessage: "length {{.Name}} must be == {{.Target}}"},
+		"min,slice":               {loperand: "len({{.Name}})", operator: "<", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must have at least {{.Target}} items"},
+		"max,slice":               {loperand: "len({{.Name}})", operator: ">", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must have at most {{.Target}} items"},
+		"unique,slice":            {condition: "hasDuplicates({{.Name}})", errorMessage: "{{.Name}} must contain unique values", helper: "hasDuplicates"},
+		"len,slice":               {loperand: "len({{.Name}})", operator: "!=", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must have {{.Target}} items"},
+		"min,map":                 {loperand: "len({{.Name}})", operator: "<", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must have at least {{.Target}} items"},
+		"max,map":                 {loperand: "len({{.Name}})", operator: ">", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must have at most {{.Target}} items"},
+		"len,map":                 {loperand: "len({{.Name}})", operator: "!=", roperand: `{{.Target}}`, errorMessage: "{{.Name}} must have {{.Target}} items"},
+		"eqfield,string":          {loperand: "{{.Name}}", operator: "!=", roperand: "{{.Field}}", errorMessage: "{{.Name}} must equal {{.Target}}"},
+		"eqfield,number":          {loperand: "{{.Name}}", operator: "!=", roperand: "{{.Field}}", errorMessage: "{{.Name}} must equal {{.Target}}"},
+		"eqfield,bool":            {loperand: "{{.Name}}", operator: "!=", roperand: "{{.Field}}", errorMessage: "{{.Name}} must equal {{.Target}}"},
+		"nefield,string":          {loperand: "{{.Name}}", operator: "==", roperand: "{{.Field}}", errorMessage: "{{.Name}} must not equal {{.Target}}"},
+		"nefield,number":          {loperand: "{{.Name}}", operator: "==", roperand: "{{.Field}}", errorMessage: "{{.Name}} must not equal {{.Target}}"},
+		"nefield,bool":            {loperand: "{{.Name}}", operator: "==", roperand: "{{.Field}}", errorMessage: "{{.Name}} must not equal {{.Target}}"},
+		"gtfield,string":          {loperand: "len({{.Name}})", operator: "<=", roperand: "len({{.Field}})", errorMessage: "length {{.Name}} must be greater than length {{.Target}}"},
+		"gtfield,number":          {loperand: "{{.Name}}", operator: "<=", roperand: "{{.Field}}", errorMessage: "{{.Name}} must be greater than {{.Target}}"},
+		"ltfield,string":          {loperand: "len({{.Name}})", operator: ">=", roperand: "len({{.Field}})", errorMessage: "length {{.Name}} must be less than length {{.Target}}"},
+		"ltfield,number":          {loperand: "{{.Name}}", operator: ">=", roperand: "{{.Field}}", errorMessage: "{{.Name}} must be less than {{.Target}}"},
+		"gtefield,string":         {loperand: "len({{.Name}})", operator: "<", roperand: "len({{.Field}})", errorMessage: "length {{.Name}} must be greater than or equal to length {{.Target}}"},
+		"gtefield,number":         {loperand: "{{.Name}}", operator: "<", roperand: "{{.Field}}", errorMessage: "{{.Name}} must be greater than or equal to {{.Target}}"},
+		"ltefield,string":         {loperand: "len({{.Name}})", operator: ">", roperand: "len({{.Field}})", errorMessage: "length {{.Name}} must be less than or equal to length {{.Target}}"},
+		"ltefield,number":         {loperand: "{{.Name}}", operator: ">", roperand: "{{.Field}}", errorMessage: "{{.Name}} must be less than or equal to {{.Target}}"},
+		"oneof,string":            {condition: "{{.OneOfQuoted}}", errorMessage: "{{.Name}} must be one of [{{.Target}}]"},
+		"oneof,number":            {condition: "{{.OneOf}}", errorMessage: "{{.Name}} must be one of [{{.Target}}]"},
+		"email,string":            {condition: "_, err := mail.ParseAddress({{.Name}}); err != nil", errorMessage: "{{.Name}} must be a valid email", imports: []string{"net/mail"}},
+		"uuid,string":             {condition: "!uuidRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be a valid UUID", imports: []string{"regexp"}, regexpName: "uuidRegexp", regexp: uuidPattern},
+		"alpha,string":            {condition: "!alphaRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must contain only letters", imports: []string{"regexp"}, regexpName: "alphaRegexp", regexp: alphaPattern},
+		"alphanum,string":         {condition: "!alphanumRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be alphanumeric", imports: []string{"regexp"}, regexpName: "alphanumRegexp", regexp: alphanumPattern},
+		"numeric,string":          {condition: "!numericRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be numeric", imports: []string{"regexp"}, regexpName: "numericRegexp", regexp: numericPattern},
+		"hexadecimal,string":      {condition: "!hexadecimalRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be hexadecimal", imports: []string{"regexp"}, regexpName: "hexadecimalRegexp", regexp: hexadecimalPattern},
+		"base64,string":           {condition: "!base64Regexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be valid base64", imports: []string{"regexp"}, regexpName: "base64Regexp", regexp: base64Pattern},
+		"ascii,string":            {condition: "!asciiRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must contain only ASCII characters", imports: []string{"regexp"}, regexpName: "asciiRegexp", regexp: asciiPattern},
+		"printascii,string":       {condition: "!printasciiRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must contain only printable ASCII characters", imports: []string{"regexp"}, regexpName: "printasciiRegexp", regexp: printasciiPattern},
+		"semver,string":           {condition: "!semverRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be a valid semantic version", imports: []string{"regexp"}, regexpName: "semverRegexp", regexp: semverPattern},
+		"e164,string":             {condition: "!e164Regexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be a valid E.164 phone number", imports: []string{"regexp"}, regexpName: "e164Regexp", regexp: e164Pattern},
+		"hostname,string":         {condition: "!hostnameRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be a valid hostname", imports: []string{"regexp"}, regexpName: "hostnameRegexp", regexp: hostnamePattern},
+		"fqdn,string":             {condition: "!fqdnRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be a valid FQDN", imports: []string{"regexp"}, regexpName: "fqdnRegexp", regexp: fqdnPattern},
+		"rgb,string":              {condition: "!rgbRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be a valid RGB color", imports: []string{"regexp"}, regexpName: "rgbRegexp", regexp: rgbPattern},
+		"rgba,string":             {condition: "!rgbaRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be a valid RGBA color", imports: []string{"regexp"}, regexpName: "rgbaRegexp", regexp: rgbaPattern},
+		"hexcolor,string":         {condition: "!hexcolorRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be a valid hex color", imports: []string{"regexp"}, regexpName: "hexcolorRegexp", regexp: hexcolorPattern},
+		"ulid,string":             {condition: "!ulidRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be a valid ULID", imports: []string{"regexp"}, regexpName: "ulidRegexp", regexp: ulidPattern},
+		"slug,string":             {condition: "!slugRegexp.MatchString({{.Name}})", errorMessage: "{{.Name}} must be a valid slug", imports: []string{"regexp"}, regexpName: "slugRegexp", regexp: slugPattern},
+		"credit_card,string":      {condition: "!luhnValid({{.Name}})", errorMessage: "{{.Name}} must be a valid credit card number", helper: "luhnValid"},
+		"latitude,float32":        {condition: "{{.Name}} < -90 || {{.Name}} > 90", errorMessage: "{{.Name}} must be a valid latitude"},
+		"latitude,float64":        {condition: "{{.Name}} < -90 || {{.Name}} > 90", errorMessage: "{{.Name}} must be a valid latitude"},
+		"longitude,float32":       {condition: "{{.Name}} < -180 || {{.Name}} > 180", errorMessage: "{{.Name}} must be a valid longitude"},
+		"longitude,float64":       {condition: "{{.Name}} < -180 || {{.Name}} > 180", errorMessage: "{{.Name}} must be a valid longitude"},
+		"regexp,string":           {condition: "!{{.Regexp}}.MatchString({{.Name}})", errorMessage: "{{.Name}} must match {{.Target}}", imports: []string{"regexp"}, regexp: "{{.Target}}"},
+		"contains,string":         {condition: "!strings.Contains({{.Name}}, {{.QuotedTarget}})", errorMessage: "{{.Name}} must contain '{{.Target}}'", imports: []string{"strings"}},
+		"excludes,string":         {condition: "strings.Contains({{.Name}}, {{.QuotedTarget}})", errorMessage: "{{.Name}} must not contain '{{.Target}}'", imports: []string{"strings"}},
+		"containsany,string":      {condition: "!strings.ContainsAny({{.Name}}, {{.QuotedTarget}})", errorMessage: "{{.Name}} must contain at least one of {{.Target}}", imports: []string{"strings"}},
+		"lowercase,string":        {loperand: "{{.Name}}", operator: "!=", roperand: "strings.ToLower({{.Name}})", errorMessage: "{{.Name}} must be lowercase", imports: []string{"strings"}},
+		"uppercase,string":        {loperand: "{{.Name}}", operator: "!=", roperand: "strings.ToUpper({{.Name}})", errorMessage: "{{.Name}} must be uppercase", imports: []string{"strings"}},
+		"startswith,string":       {condition: "!strings.HasPrefix({{.Name}}, {{.QuotedTarget}})", errorMessage: "{{.Name}} must start with '{{.Target}}'", imports: []string{"strings"}},
+		"endswith,string":         {condition: "!strings.HasSuffix({{.Name}}, {{.QuotedTarget}})", errorMessage: "{{.Name}} must end with '{{.Target}}'", imports: []string{"strings"}},
+		"url,string":              {condition: `u, err := url.ParseRequestURI({{.Name}}); err != nil || u.Scheme == ""`, errorMessage: "{{.Name}} must be a valid URL", imports: []string{"net/url"}},
+		"datetime,string":         {condition: "_, err := time.Parse({{.QuotedTarget}}, {{.Name}}); err != nil", errorMessage: "{{.Name}} must match format {{.Target}}", imports: []string{"time"}},
+		"ipv4,string":             {condition: "ip := net.ParseIP({{.Name}}); ip == nil || ip.To4() == nil", errorMessage: "{{.Name}} must be a valid IPv4 address", imports: []string{"net"}},
+		"ipv6,string":             {condition: "ip := net.ParseIP({{.Name}}); ip == nil || ip.To4() != nil", errorMessage: "{{.Name}} must be a valid IPv6 address", imports: []string{"net"}},
+		"ip,string":               {condition: "net.ParseIP({{.Name}}) == nil", errorMessage: "{{.Name}} must be a valid IP address", imports: []string{"net"}},
+		"mac,string":              {condition: "_, err := net.ParseMAC({{.Name}}); err != nil", errorMessage: "{{.Name}} must be a valid MAC address", imports: []string{"net"}},
+		"json,string":             {condition: "!json.Valid([]byte({{.Name}}))", errorMessage: "{{.Name}} must be valid JSON", imports: []string{"encoding/json"}},
+		"json,[]byte":             {condition: "!json.Valid({{.Name}})", errorMessage: "{{.Name}} must be valid JSON", imports: []string{"encoding/json"}},
+	}
+
+	validation, target, _ := strings.Cut(fieldValidation, "=")
+	if validation == "between" {
+		return betweenTestElements(operand, fieldName, target, fieldType)
+	}
+
+	// Validations of a specific type take precedence over the ones of its kind.
+	ifData, ok := ifCode[validation+","+fieldType]
+	if !ok {
+		ifData, ok = ifCode[validation+","+typeKind(fieldType)]
+	}
+	if validation == "isdefault" {
+		if _, notEmpty, err := emptyConditions("{{.Name}}", fieldType); err == nil {
+			ifData, ok = FieldTestElements{condition: notEmpty, errorMessage: "{{.Name}} must be the default value"}, true
+		}
+	}
+	if customValidation, isCustom := customValidations[validation]; isCustom {
+		var err error
+		if ifData, err = customValidation(fieldName, target, fieldType); err != nil {
+			return FieldTestElements{}, err
+		}
+		ok = true
+	}
+
+	if !ok {
+		if !isKnownValidation(ifCode, validation) && validation != "isdefault" {
+			return FieldTestElements{}, fmt.Errorf("unknown validation %q for field %s", validation, fieldName)
+		}
+
+		return FieldTestElements{}, fmt.Errorf("unsupported validation %s for field %s of type %s", fieldValidation, fieldName, fieldType)
+	}
+
+	if target == "" && requiresArgument(ifData) {
+		return FieldTestElements{}, fmt.Errorf("validation %s for field %s requires an argument", validation, fieldName)
+	}
+
+	if isUnsigned(fieldType) && strings.HasPrefix(target, "-") {
+		return FieldTestElements{}, fmt.Errorf("validation %s for field %s has a negative bound, but type %s is unsigned", fieldValidation, fieldName, fieldType)
+	}
+
+	if validation == "unique" && !isComparable(strings.TrimPrefix(fieldType, "[]")) {
+		return FieldTestElements{}, fmt.Errorf("validation unique for field %s requires comparable elements instead of %s", fieldName, fieldType)
+	}
+
+	// Cross-field validations reference a field instead of a literal.
+	if fieldType == "bool" && !crossFieldValidations[validation] && target != "" && target != "true" && target != "false" {
+		return FieldTestElements{}, fmt.Errorf("invalid bool value %s in validation %s for field %s", target, fieldValidation, fieldName)
+	}
+
+	codeReplacer := strings.NewReplacer(
+		"{{.Name}}", operand,
+		"{{.Target}}", target,
+		"{{.QuotedTarget}}", strconv.Quote(target),
+		"{{.OneOf}}", oneOfCondition(operand, target, false),
+		"{{.OneOfQuoted}}", oneOfCondition(operand, target, true),
+		"{{.Field}}", "obj."+target,
+	)
+	messageReplacer := strings.NewReplacer(
+		"{{.Name}}", fieldName,
+		"{{.Target}}", strings.Replace(target, "%", "%%", -1),
+	)
+
+	ifData.loperand = codeReplacer.Replace(ifData.loperand)
+	ifData.roperand = codeReplacer.Replace(ifData.roperand)
+	ifData.condition = codeReplacer.Replace(ifData.condition)
+	ifData.regexp = strings.Replace(ifData.regexp, "{{.Target}}", target, -1)
+	ifData.errorMessage = messageReplacer.Replace(ifData.errorMessage)
+
+	// The generated code compiles the pattern when the package is initialized.
+	if ifData.regexp != "" {
+		if _, err := regexp.Compile(ifData.regexp); err != nil {
+			return FieldTestElements{}, fmt.Errorf("invalid pattern in validation %s for field %s: %w", fieldValidation, fieldName, err)
+		}
+	}
+
+	return ifData, nil
+}
+
+// requiresArgument reports whether the test elements use the argument of the
+// validation.
+func requiresArgument(testElements FieldTestElements) bool {
+	for _, code := range []string{testElements.loperand, testElements.roperand, testElements.condition, testElements.regexp} {
+		for _, placeholder := range []string{"{{.Target}}", "{{.QuotedTarget}}", "{{.OneOf}}", "{{.OneOfQuoted}}", "{{.Field}}"} {
+			if strings.Contains(code, placeholder) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isKnownValidation(ifCode map[string]FieldTestElements, validation string) bool {
+	for key := range ifCode {
+		if strings.HasPrefix(key, validation+",") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isUnsigned reports whether the type is an unsigned integer.
+func isUnsigned(fieldType string) bool {
+	return strings.HasPrefix(fieldType, "uint") || fieldType == "byte"
+}
+
+// isComparable reports whether values of the type can be map keys. Named
+// types are assumed comparable and left to the compiler.
+func isComparable(fieldType string) bool {
+	for _, prefix := range []string{"[]", "map[", "func("} {
+		if strings.HasPrefix(fieldType, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// oneOfCondition builds a condition that is true when operand matches none of
+// the space separated values.
+func oneOfCondition(operand, values string, quoted bool) string {
+	var tests []string
+	for _, value := range strings.Fields(values) {
+		if quoted {
+			value = strconv.Quote(value)
+		}
+		tests = append(tests, operand+" != "+value)
+	}
+
+	return strings.Join(tests, " && ")
+}
+
+// typeKind groups field types that share the same validation code.
+func typeKind(fieldType string) string {
+	switch fieldType {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"byte", "rune", "float32", "float64":
+		return "number"
+	}
+
+	if strings.HasPrefix(fieldType, "[]") {
+		return "slice"
+	}
+
+	if strings.HasPrefix(fieldType, "map[") {
+		return "map"
+	}
+
+	if strings.HasPrefix(fieldType, "*") {
+		return "pointer"
+	}
+
+	if fieldType == "any" || strings.HasPrefix(fieldType, "interface{") {
+		return "interface"
+	}
+
+	return fieldType
+}
+
+func (s *StructInfo) GenerateFileValidator() error {
+	code, err := s.GenerateValidator()
+	if err != nil {
+		return err
+	}
+
+	return s.writeValidatorFile(code)
+}
+
+// writeValidatorFile writes the validator code of the struct to its file.
+func (s *StructInfo) writeValidatorFile(code string) error {
+	fmt.Printf("Generating struct %s validations code\n", s.Name)
+
+	if err := os.WriteFile(s.Path+"/"+strings.ToLower(s.Name)+"_validator.go", []byte(code), 0644); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *StructInfo) GenerateFilePackageDefinition() error {
+	fmt.Println("Generating package definitions code")
+
+	code, err := s.Generate()
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(s.Path+"/validators.go", []byte(code), 0644); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *StructInfo) Generate() (string, error) {
+	if strings.Contains(s.Sentinel(), ".") {
+		return "", fmt.Errorf("sentinel %s of another package cannot be declared", s.Sentinel())
+	}
+
+	tmpl, err := template.New("PkgDef").Parse(packageDefinitionTpl)
+	if err != nil {
+		return "", err
+	}
+
+	code := new(bytes.Buffer)
+	if err := tmpl.Execute(code, s); err != nil {
+		return "", err
+	}
+
+	return code.String(), nil
+}
+
+// Field returns the struct field with the given name.
+func (s *StructInfo) Field(name string) (FieldInfo, bool) {
+	for _, fieldInfo := range s.FieldsInfo {
+		if fieldInfo.Name == name {
+			return fieldInfo, true
+		}
+	}
+
+	return FieldInfo{}, false
 }
 
 func (s *StructInfo) PrintInfo() {
@@ -1,7 +1,13 @@
 package main
 
 import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
@@ -46,14 +52,15 @@ import (
 	"fmt"
 )
 
+// UserValidate validates a User and returns all validation errors.
 func UserValidate(obj *User) []error {
 	var errs []error
 
-	if !(obj.FirstName != "") {
+	if obj.FirstName == "" {
 		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
 	}
 
-	if !(obj.MyAge != 0) {
+	if obj.MyAge == 0 {
 		errs = append(errs, fmt.Errorf("%w: MyAge required", ErrValidation))
 	}
 
@@ -85,10 +92,11 @@ import (
 	"fmt"
 )
 
+// UserValidate validates a User and returns all validation errors.
 func UserValidate(obj *User) []error {
 	var errs []error
 
-	if !(len(obj.FirstName) >= 5) {
+	if len(obj.FirstName) < 5 {
 		errs = append(errs, fmt.Errorf("%w: length FirstName must be >= 5", ErrValidation))
 	}
 
@@ -97,141 +105,4993 @@ func UserValidate(obj *User) []error {
 `,
 			wantErr: false,
 		},
-	}
+		{
+			name: "Email imports net/mail",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Email",
+							Type:        "string",
+							Tag:         `validate:"required,email"`,
+							Validations: []string{"required", "email"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			fv := tt.fields.StructInfo
-			got, err := fv.GenerateValidator()
-			if (err != nil) != tt.wantErr {
-				t.Errorf("FileValidator.Generate() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if got != tt.want {
-				t.Errorf("FileValidator.Generate() = %v, want %v", got, tt.want)
-				dmp := diffmatchpatch.New()
-				diffs := dmp.DiffMain(tt.want, got, false)
-				if len(diffs) > 1 {
-					t.Errorf("FileValidator.Generate() diff = \n%v", dmp.DiffPrettyText(diffs))
-				}
-			}
-		})
+import (
+	"fmt"
+	"net/mail"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.Email == "" {
+		errs = append(errs, fmt.Errorf("%w: Email required", ErrValidation))
 	}
-}
 
-func TestGetFieldTestElements(t *testing.T) {
-	type args struct {
-		fieldName       string
-		fieldValidation string
-		fieldType       string
+	if _, err := mail.ParseAddress(obj.Email); err != nil {
+		errs = append(errs, fmt.Errorf("%w: Email must be a valid email", ErrValidation))
 	}
-	tests := []struct {
-		name    string
-		args    args
-		want    FieldTestElements
-		wantErr bool
-	}{
-		{
-			name: "Required string",
-			args: args{
-				fieldName:       "myfield1",
-				fieldValidation: "required",
-				fieldType:       "string",
-			},
-			want: FieldTestElements{
-				loperand:     "obj.myfield1",
-				operator:     "!=",
-				roperand:     `""`,
-				errorMessage: "myfield1 required",
-			},
+
+	return errs
+}
+`,
 			wantErr: false,
 		},
 		{
-			name: "Required uint8",
-			args: args{
-				fieldName:       "myfield2",
-				fieldValidation: "required",
-				fieldType:       "uint8",
-			},
-			want: FieldTestElements{
-				loperand:     "obj.myfield2",
-				operator:     "!=",
-				roperand:     `0`,
-				errorMessage: "myfield2 required",
+			name: "UUID regexp declared once",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "ID",
+							Type:        "string",
+							Tag:         `validate:"uuid"`,
+							Validations: []string{"uuid"},
+						},
+						{
+							Name:        "ParentID",
+							Type:        "string",
+							Tag:         `validate:"uuid"`,
+							Validations: []string{"uuid"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
 			},
+			want: `package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var uuidRegexp = regexp.MustCompile(` + "`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`" + `)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if !uuidRegexp.MatchString(obj.ID) {
+		errs = append(errs, fmt.Errorf("%w: ID must be a valid UUID", ErrValidation))
+	}
+
+	if !uuidRegexp.MatchString(obj.ParentID) {
+		errs = append(errs, fmt.Errorf("%w: ParentID must be a valid UUID", ErrValidation))
+	}
+
+	return errs
+}
+`,
 			wantErr: false,
 		},
 		{
-			name: "uint8 >= 0",
-			args: args{
-				fieldName:       "myfield3",
-				fieldValidation: "gte=0",
-				fieldType:       "uint8",
-			},
-			want: FieldTestElements{
-				loperand:     "obj.myfield3",
-				operator:     ">=",
-				roperand:     `0`,
-				errorMessage: "myfield3 must be >= 0",
+			name: "Hexadecimal and base64 regexps are declared once",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Token",
+							Type:        "string",
+							Tag:         `validate:"hexadecimal,base64"`,
+							Validations: []string{"hexadecimal", "base64"},
+						},
+						{
+							Name:        "OtherToken",
+							Type:        "string",
+							Tag:         `validate:"base64,hexadecimal"`,
+							Validations: []string{"base64", "hexadecimal"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
 			},
+			want: `package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var hexadecimalRegexp = regexp.MustCompile(` + "`^[0-9a-fA-F]+$`" + `)
+
+var base64Regexp = regexp.MustCompile(` + "`^(?:[A-Za-z0-9+/]{4})*(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=)?$`" + `)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if !hexadecimalRegexp.MatchString(obj.Token) {
+		errs = append(errs, fmt.Errorf("%w: Token must be hexadecimal", ErrValidation))
+	}
+
+	if !base64Regexp.MatchString(obj.Token) {
+		errs = append(errs, fmt.Errorf("%w: Token must be valid base64", ErrValidation))
+	}
+
+	if !base64Regexp.MatchString(obj.OtherToken) {
+		errs = append(errs, fmt.Errorf("%w: OtherToken must be valid base64", ErrValidation))
+	}
+
+	if !hexadecimalRegexp.MatchString(obj.OtherToken) {
+		errs = append(errs, fmt.Errorf("%w: OtherToken must be hexadecimal", ErrValidation))
+	}
+
+	return errs
+}
+`,
 			wantErr: false,
 		},
 		{
-			name: "uint8 <= 130",
-			args: args{
-				fieldName:       "myfield4",
-				fieldValidation: "lte=130",
-				fieldType:       "uint8",
-			},
-			want: FieldTestElements{
-				loperand:     "obj.myfield4",
-				operator:     "<=",
-				roperand:     `130`,
-				errorMessage: "myfield4 must be <= 130",
+			name: "ASCII ranges are kept in the regexp literals",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Login",
+							Type:        "string",
+							Tag:         `validate:"ascii"`,
+							Validations: []string{"ascii"},
+						},
+						{
+							Name:        "Label",
+							Type:        "string",
+							Tag:         `validate:"printascii"`,
+							Validations: []string{"printascii"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
 			},
+			want: `package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var asciiRegexp = regexp.MustCompile(` + "`^[\\x00-\\x7F]*$`" + `)
+
+var printasciiRegexp = regexp.MustCompile(` + "`^[\\x20-\\x7E]*$`" + `)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if !asciiRegexp.MatchString(obj.Login) {
+		errs = append(errs, fmt.Errorf("%w: Login must contain only ASCII characters", ErrValidation))
+	}
+
+	if !printasciiRegexp.MatchString(obj.Label) {
+		errs = append(errs, fmt.Errorf("%w: Label must contain only printable ASCII characters", ErrValidation))
+	}
+
+	return errs
+}
+`,
 			wantErr: false,
 		},
 		{
-			name: "String size >= 5",
-			args: args{
-				fieldName:       "myfield5",
-				fieldValidation: "gte=5",
-				fieldType:       "string",
-			},
-			want: FieldTestElements{
-				loperand:     "len(obj.myfield5)",
-				operator:     ">=",
-				roperand:     `5`,
-				errorMessage: "length myfield5 must be >= 5",
+			name: "Hostname and FQDN regexps are declared apart",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Server",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Host",
+							Type:        "string",
+							Tag:         `validate:"hostname"`,
+							Validations: []string{"hostname"},
+						},
+						{
+							Name:        "Domain",
+							Type:        "string",
+							Tag:         `validate:"fqdn"`,
+							Validations: []string{"fqdn"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
 			},
+			want: `package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var hostnameRegexp = regexp.MustCompile(` + "`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`" + `)
+
+var fqdnRegexp = regexp.MustCompile(` + "`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\\.)+[a-zA-Z]{2,63}\\.?$`" + `)
+
+// ServerValidate validates a Server and returns all validation errors.
+func ServerValidate(obj *Server) []error {
+	var errs []error
+
+	if !hostnameRegexp.MatchString(obj.Host) {
+		errs = append(errs, fmt.Errorf("%w: Host must be a valid hostname", ErrValidation))
+	}
+
+	if !fqdnRegexp.MatchString(obj.Domain) {
+		errs = append(errs, fmt.Errorf("%w: Domain must be a valid FQDN", ErrValidation))
+	}
+
+	return errs
+}
+`,
 			wantErr: false,
 		},
 		{
-			name: "String size <= 10",
-			args: args{
-				fieldName:       "myfield6",
-				fieldValidation: "lte=10",
-				fieldType:       "string",
-			},
-			want: FieldTestElements{
-				loperand:     "len(obj.myfield6)",
-				operator:     "<=",
-				roperand:     `10`,
-				errorMessage: "length myfield6 must be <= 10",
-			},
-			wantErr: false,
-		},
+			name: "RGB and RGBA regexps are declared once",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Theme",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Foreground",
+							Type:        "string",
+							Tag:         `validate:"rgb"`,
+							Validations: []string{"rgb"},
+						},
+						{
+							Name:        "Background",
+							Type:        "string",
+							Tag:         `validate:"rgba"`,
+							Validations: []string{"rgba"},
+						},
+						{
+							Name:        "Border",
+							Type:        "string",
+							Tag:         `validate:"rgb"`,
+							Validations: []string{"rgb"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var rgbRegexp = regexp.MustCompile(` + "`" + rgbPattern + "`" + `)
+
+var rgbaRegexp = regexp.MustCompile(` + "`" + rgbaPattern + "`" + `)
+
+// ThemeValidate validates a Theme and returns all validation errors.
+func ThemeValidate(obj *Theme) []error {
+	var errs []error
+
+	if !rgbRegexp.MatchString(obj.Foreground) {
+		errs = append(errs, fmt.Errorf("%w: Foreground must be a valid RGB color", ErrValidation))
+	}
+
+	if !rgbaRegexp.MatchString(obj.Background) {
+		errs = append(errs, fmt.Errorf("%w: Background must be a valid RGBA color", ErrValidation))
+	}
+
+	if !rgbRegexp.MatchString(obj.Border) {
+		errs = append(errs, fmt.Errorf("%w: Border must be a valid RGB color", ErrValidation))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Omitempty skips cross-field comparisons of empty fields",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name: "Password",
+							Type: "string",
+						},
+						{
+							Name:        "Confirm",
+							Type:        "string",
+							Tag:         `validate:"omitempty,eqfield=Password"`,
+							Validations: []string{"omitempty", "eqfield=Password"},
+						},
+						{
+							Name: "MinAge",
+							Type: "int",
+						},
+						{
+							Name:        "MaxAge",
+							Type:        "*int",
+							Tag:         `validate:"omitempty,gtfield=MinAge"`,
+							Validations: []string{"omitempty", "gtfield=MinAge"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.Confirm != "" {
+		if obj.Confirm != obj.Password {
+			errs = append(errs, fmt.Errorf("%w: Confirm must equal Password", ErrValidation))
+		}
+	}
+
+	if obj.MaxAge != nil {
+		if *obj.MaxAge <= obj.MinAge {
+			errs = append(errs, fmt.Errorf("%w: MaxAge must be greater than MinAge", ErrValidation))
+		}
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Regexp patterns are shared",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Code",
+							Type:        "string",
+							Tag:         `validate:"regexp=^[A-Z]{3}$"`,
+							Validations: []string{"regexp=^[A-Z]{3}$"},
+						},
+						{
+							Name:        "OtherCode",
+							Type:        "string",
+							Tag:         `validate:"regexp=^[A-Z]{3}$"`,
+							Validations: []string{"regexp=^[A-Z]{3}$"},
+						},
+						{
+							Name:        "Query",
+							Type:        "string",
+							Tag:         `validate:"regexp=^\\w+=\\d+$"`,
+							Validations: []string{`regexp=^\w+=\d+$`},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var fieldPattern0 = regexp.MustCompile(` + "`^[A-Z]{3}$`" + `)
+
+var fieldPattern1 = regexp.MustCompile(` + "`^\\w+=\\d+$`" + `)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if !fieldPattern0.MatchString(obj.Code) {
+		errs = append(errs, fmt.Errorf("%w: Code must match ^[A-Z]{3}$", ErrValidation))
+	}
+
+	if !fieldPattern0.MatchString(obj.OtherCode) {
+		errs = append(errs, fmt.Errorf("%w: OtherCode must match ^[A-Z]{3}$", ErrValidation))
+	}
+
+	if !fieldPattern1.MatchString(obj.Query) {
+		errs = append(errs, fmt.Errorf("%w: Query must match ^\\w+=\\d+$", ErrValidation))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Contains imports strings",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Email",
+							Type:        "string",
+							Tag:         `validate:"contains=@"`,
+							Validations: []string{"contains=@"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if !strings.Contains(obj.Email, "@") {
+		errs = append(errs, fmt.Errorf("%w: Email must contain '@'", ErrValidation))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "FirstName must have 5 runes or more",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "FirstName",
+							Type:        "string",
+							Tag:         `validate:"gte=5,len=8"`,
+							Validations: []string{"gte=5", "len=8"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+					CountRunes:     true,
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if utf8.RuneCountInString(obj.FirstName) < 5 {
+		errs = append(errs, fmt.Errorf("%w: length FirstName must be >= 5", ErrValidation))
+	}
+
+	if utf8.RuneCountInString(obj.FirstName) != 8 {
+		errs = append(errs, fmt.Errorf("%w: length FirstName must be == 8", ErrValidation))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Nested struct",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "FirstName",
+							Type:        "string",
+							Tag:         `validate:"required"`,
+							Validations: []string{"required"},
+						},
+						{
+							Name:        "Address",
+							Type:        "Address",
+							Tag:         `validate:"required"`,
+							Validations: []string{"required"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+					NestedStructs:  map[string]bool{"User": true, "Address": true},
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
+	}
+
+	for _, err := range AddressValidate(&obj.Address) {
+		errs = append(errs, fmt.Errorf("Address: %w", err))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Pointer fields",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Nickname",
+							Type:        "*string",
+							Tag:         `validate:"required,gte=5"`,
+							Validations: []string{"required", "gte=5"},
+						},
+						{
+							Name:        "Manager",
+							Type:        "*User",
+							Tag:         `validate:"required"`,
+							Validations: []string{"required"},
+						},
+						{
+							Name: "Mentor",
+							Type: "*User",
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+					NestedStructs:  map[string]bool{"User": true},
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.Nickname == nil {
+		errs = append(errs, fmt.Errorf("%w: Nickname required", ErrValidation))
+	}
+
+	if obj.Nickname != nil {
+		if len(*obj.Nickname) < 5 {
+			errs = append(errs, fmt.Errorf("%w: length Nickname must be >= 5", ErrValidation))
+		}
+	}
+
+	if obj.Manager == nil {
+		errs = append(errs, fmt.Errorf("%w: Manager required", ErrValidation))
+	}
+
+	if obj.Manager != nil {
+		for _, err := range UserValidate(obj.Manager) {
+			errs = append(errs, fmt.Errorf("Manager: %w", err))
+		}
+	}
+
+	if obj.Mentor != nil {
+		for _, err := range UserValidate(obj.Mentor) {
+			errs = append(errs, fmt.Errorf("Mentor: %w", err))
+		}
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Dive into slices",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Tags",
+							Type:        "[]string",
+							Tag:         `validate:"len=3,dive,gte=2"`,
+							Validations: []string{"len=3", "dive", "gte=2"},
+						},
+						{
+							Name:        "Addresses",
+							Type:        "[]Address",
+							Tag:         `validate:"dive"`,
+							Validations: []string{"dive"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+					NestedStructs:  map[string]bool{"User": true, "Address": true},
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if len(obj.Tags) != 3 {
+		errs = append(errs, fmt.Errorf("%w: Tags must have 3 items", ErrValidation))
+	}
+
+	for i := range obj.Tags {
+		if len(obj.Tags[i]) < 2 {
+			errs = append(errs, fmt.Errorf("%w: length Tags[%d] must be >= 2", ErrValidation, i))
+		}
+	}
+
+	for i := range obj.Addresses {
+		for _, err := range AddressValidate(&obj.Addresses[i]) {
+			errs = append(errs, fmt.Errorf("Addresses[%d]: %w", i, err))
+		}
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Dive into a non slice",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "FirstName",
+							Type:        "string",
+							Tag:         `validate:"dive,gte=2"`,
+							Validations: []string{"dive", "gte=2"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "Omit empty values",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Nickname",
+							Type:        "string",
+							Tag:         `validate:"omitempty,gte=5"`,
+							Validations: []string{"omitempty", "gte=5"},
+						},
+						{
+							Name:        "Age",
+							Type:        "uint8",
+							Tag:         `validate:"omitempty,gte=18,lte=130"`,
+							Validations: []string{"omitempty", "gte=18", "lte=130"},
+						},
+						{
+							Name:        "Notes",
+							Type:        "string",
+							Tag:         `validate:"omitempty"`,
+							Validations: []string{"omitempty"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.Nickname != "" {
+		if len(obj.Nickname) < 5 {
+			errs = append(errs, fmt.Errorf("%w: length Nickname must be >= 5", ErrValidation))
+		}
+	}
+
+	if obj.Age != 0 {
+		if obj.Age < 18 {
+			errs = append(errs, fmt.Errorf("%w: Age must be >= 18", ErrValidation))
+		}
+
+		if obj.Age > 130 {
+			errs = append(errs, fmt.Errorf("%w: Age must be <= 130", ErrValidation))
+		}
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Equal to another field",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Password",
+							Type:        "string",
+							Tag:         `validate:"required"`,
+							Validations: []string{"required"},
+						},
+						{
+							Name:        "Confirm",
+							Type:        "string",
+							Tag:         `validate:"eqfield=Password"`,
+							Validations: []string{"eqfield=Password"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.Password == "" {
+		errs = append(errs, fmt.Errorf("%w: Password required", ErrValidation))
+	}
+
+	if obj.Confirm != obj.Password {
+		errs = append(errs, fmt.Errorf("%w: Confirm must equal Password", ErrValidation))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Equal to an unknown field",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Confirm",
+							Type:        "string",
+							Tag:         `validate:"eqfield=Password"`,
+							Validations: []string{"eqfield=Password"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "Not equal to other field",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Account",
+					FieldsInfo: []FieldInfo{
+						{
+							Name: "PersonalEmail",
+							Type: "string",
+						},
+						{
+							Name:        "BillingEmail",
+							Type:        "string",
+							Tag:         `validate:"nefield=PersonalEmail"`,
+							Validations: []string{"nefield=PersonalEmail"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// AccountValidate validates a Account and returns all validation errors.
+func AccountValidate(obj *Account) []error {
+	var errs []error
+
+	if obj.BillingEmail == obj.PersonalEmail {
+		errs = append(errs, fmt.Errorf("%w: BillingEmail must not equal PersonalEmail", ErrValidation))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Not equal to unknown field",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Account",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "BillingEmail",
+							Type:        "string",
+							Tag:         `validate:"nefield=PersonalEmail"`,
+							Validations: []string{"nefield=PersonalEmail"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "Greater and less than other fields",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Range",
+					FieldsInfo: []FieldInfo{
+						{
+							Name: "Min",
+							Type: "int",
+						},
+						{
+							Name:        "Max",
+							Type:        "int",
+							Tag:         `validate:"gtfield=Min"`,
+							Validations: []string{"gtfield=Min"},
+						},
+						{
+							Name: "Title",
+							Type: "string",
+						},
+						{
+							Name:        "Summary",
+							Type:        "string",
+							Tag:         `validate:"ltfield=Title"`,
+							Validations: []string{"ltfield=Title"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// RangeValidate validates a Range and returns all validation errors.
+func RangeValidate(obj *Range) []error {
+	var errs []error
+
+	if obj.Max <= obj.Min {
+		errs = append(errs, fmt.Errorf("%w: Max must be greater than Min", ErrValidation))
+	}
+
+	if len(obj.Summary) >= len(obj.Title) {
+		errs = append(errs, fmt.Errorf("%w: length Summary must be less than length Title", ErrValidation))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Greater or equal and less or equal than other fields",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Range",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Min",
+							Type:        "int",
+							Tag:         `validate:"ltefield=Max"`,
+							Validations: []string{"ltefield=Max"},
+						},
+						{
+							Name:        "Max",
+							Type:        "int",
+							Tag:         `validate:"gtefield=Min"`,
+							Validations: []string{"gtefield=Min"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// RangeValidate validates a Range and returns all validation errors.
+func RangeValidate(obj *Range) []error {
+	var errs []error
+
+	if obj.Min > obj.Max {
+		errs = append(errs, fmt.Errorf("%w: Min must be less than or equal to Max", ErrValidation))
+	}
+
+	if obj.Max < obj.Min {
+		errs = append(errs, fmt.Errorf("%w: Max must be greater than or equal to Min", ErrValidation))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Greater or equal than a field of another type",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Range",
+					FieldsInfo: []FieldInfo{
+						{
+							Name: "Min",
+							Type: "int64",
+						},
+						{
+							Name:        "Max",
+							Type:        "int",
+							Tag:         `validate:"gtefield=Min"`,
+							Validations: []string{"gtefield=Min"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "Greater than a field of another type",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Range",
+					FieldsInfo: []FieldInfo{
+						{
+							Name: "Min",
+							Type: "string",
+						},
+						{
+							Name:        "Max",
+							Type:        "int",
+							Tag:         `validate:"gtfield=Min"`,
+							Validations: []string{"gtfield=Min"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "Required if other fields have a value",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name: "HasCar",
+							Type: "bool",
+						},
+						{
+							Name: "Country",
+							Type: "string",
+						},
+						{
+							Name:        "DriverLicense",
+							Type:        "string",
+							Tag:         `validate:"required_if=HasCar true"`,
+							Validations: []string{"required_if=HasCar true"},
+						},
+						{
+							Name:        "TaxID",
+							Type:        "string",
+							Tag:         `validate:"required_if=Country BR"`,
+							Validations: []string{"required_if=Country BR"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.HasCar == true {
+		if obj.DriverLicense == "" {
+			errs = append(errs, fmt.Errorf("%w: DriverLicense required when HasCar is true", ErrValidation))
+		}
+	}
+
+	if obj.Country == "BR" {
+		if obj.TaxID == "" {
+			errs = append(errs, fmt.Errorf("%w: TaxID required when Country is BR", ErrValidation))
+		}
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Excluded if other fields have values",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Order",
+					FieldsInfo: []FieldInfo{
+						{
+							Name: "IsGift",
+							Type: "bool",
+						},
+						{
+							Name: "Channel",
+							Type: "string",
+						},
+						{
+							Name:        "Coupon",
+							Type:        "string",
+							Tag:         `validate:"excluded_if=IsGift true"`,
+							Validations: []string{"excluded_if=IsGift true"},
+						},
+						{
+							Name:        "Discount",
+							Type:        "int",
+							Tag:         `validate:"excluded_if=Channel partner"`,
+							Validations: []string{"excluded_if=Channel partner"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// OrderValidate validates a Order and returns all validation errors.
+func OrderValidate(obj *Order) []error {
+	var errs []error
+
+	if obj.IsGift == true {
+		if obj.Coupon != "" {
+			errs = append(errs, fmt.Errorf("%w: Coupon must be empty when IsGift is true", ErrValidation))
+		}
+	}
+
+	if obj.Channel == "partner" {
+		if obj.Discount != 0 {
+			errs = append(errs, fmt.Errorf("%w: Discount must be empty when Channel is partner", ErrValidation))
+		}
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Required if an unknown field has a value",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "DriverLicense",
+							Type:        "string",
+							Tag:         `validate:"required_if=HasCar true"`,
+							Validations: []string{"required_if=HasCar true"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "Required with and without other fields",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Contact",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "Email",
+							Type:        "string",
+							Tag:         `validate:"required_without=Phone"`,
+							Validations: []string{"required_without=Phone"},
+						},
+						{
+							Name: "Phone",
+							Type: "string",
+						},
+						{
+							Name:        "Extension",
+							Type:        "uint16",
+							Tag:         `validate:"required_with=Phone"`,
+							Validations: []string{"required_with=Phone"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// ContactValidate validates a Contact and returns all validation errors.
+func ContactValidate(obj *Contact) []error {
+	var errs []error
+
+	if obj.Phone == "" {
+		if obj.Email == "" {
+			errs = append(errs, fmt.Errorf("%w: Email required when Phone is empty", ErrValidation))
+		}
+	}
+
+	if obj.Phone != "" {
+		if obj.Extension == 0 {
+			errs = append(errs, fmt.Errorf("%w: Extension required when Phone is present", ErrValidation))
+		}
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Conditional validations of pointers",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Driver",
+					FieldsInfo: []FieldInfo{
+						{
+							Name: "HasCar",
+							Type: "bool",
+						},
+						{
+							Name:        "License",
+							Type:        "*string",
+							Tag:         `validate:"required_if=HasCar true,gte=5"`,
+							Validations: []string{"required_if=HasCar true", "gte=5"},
+						},
+						{
+							Name:        "Coupon",
+							Type:        "*string",
+							Tag:         `validate:"excluded_if=HasCar true"`,
+							Validations: []string{"excluded_if=HasCar true"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// DriverValidate validates a Driver and returns all validation errors.
+func DriverValidate(obj *Driver) []error {
+	var errs []error
+
+	if obj.HasCar == true {
+		if obj.License == nil {
+			errs = append(errs, fmt.Errorf("%w: License required when HasCar is true", ErrValidation))
+		}
+	}
+
+	if obj.License != nil {
+		if len(*obj.License) < 5 {
+			errs = append(errs, fmt.Errorf("%w: length License must be >= 5", ErrValidation))
+		}
+	}
+
+	if obj.HasCar == true {
+		if obj.Coupon != nil {
+			errs = append(errs, fmt.Errorf("%w: Coupon must be empty when HasCar is true", ErrValidation))
+		}
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Required with and without other fields on pointers",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Contact",
+					FieldsInfo: []FieldInfo{
+						{
+							Name: "Phone",
+							Type: "string",
+						},
+						{
+							Name: "Mobile",
+							Type: "string",
+						},
+						{
+							Name:        "Email",
+							Type:        "*string",
+							Tag:         `validate:"required_without=Phone"`,
+							Validations: []string{"required_without=Phone"},
+						},
+						{
+							Name:        "Extension",
+							Type:        "*uint16",
+							Tag:         `validate:"required_with=Phone"`,
+							Validations: []string{"required_with=Phone"},
+						},
+						{
+							Name:        "Fax",
+							Type:        "*string",
+							Tag:         `validate:"required_without_all=Phone Mobile"`,
+							Validations: []string{"required_without_all=Phone Mobile"},
+						},
+						{
+							Name:        "Carrier",
+							Type:        "*string",
+							Tag:         `validate:"required_with_all=Phone Mobile"`,
+							Validations: []string{"required_with_all=Phone Mobile"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// ContactValidate validates a Contact and returns all validation errors.
+func ContactValidate(obj *Contact) []error {
+	var errs []error
+
+	if obj.Phone == "" {
+		if obj.Email == nil {
+			errs = append(errs, fmt.Errorf("%w: Email required when Phone is empty", ErrValidation))
+		}
+	}
+
+	if obj.Phone != "" {
+		if obj.Extension == nil {
+			errs = append(errs, fmt.Errorf("%w: Extension required when Phone is present", ErrValidation))
+		}
+	}
+
+	if obj.Phone == "" && obj.Mobile == "" {
+		if obj.Fax == nil {
+			errs = append(errs, fmt.Errorf("%w: Fax required when Phone and Mobile are empty", ErrValidation))
+		}
+	}
+
+	if obj.Phone != "" && obj.Mobile != "" {
+		if obj.Carrier == nil {
+			errs = append(errs, fmt.Errorf("%w: Carrier required when Phone and Mobile are present", ErrValidation))
+		}
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Required with and without all other fields",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "Contact",
+					FieldsInfo: []FieldInfo{
+						{
+							Name: "Phone",
+							Type: "string",
+						},
+						{
+							Name: "Mobile",
+							Type: "string",
+						},
+						{
+							Name:        "Email",
+							Type:        "string",
+							Tag:         `validate:"required_without_all=Phone Mobile"`,
+							Validations: []string{"required_without_all=Phone Mobile"},
+						},
+						{
+							Name:        "Carrier",
+							Type:        "string",
+							Tag:         `validate:"required_with_all=Phone Mobile"`,
+							Validations: []string{"required_with_all=Phone Mobile"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// ContactValidate validates a Contact and returns all validation errors.
+func ContactValidate(obj *Contact) []error {
+	var errs []error
+
+	if obj.Phone == "" && obj.Mobile == "" {
+		if obj.Email == "" {
+			errs = append(errs, fmt.Errorf("%w: Email required when Phone and Mobile are empty", ErrValidation))
+		}
+	}
+
+	if obj.Phone != "" && obj.Mobile != "" {
+		if obj.Carrier == "" {
+			errs = append(errs, fmt.Errorf("%w: Carrier required when Phone and Mobile are present", ErrValidation))
+		}
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Structured errors",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "FirstName",
+							Type:        "string",
+							Tag:         `validate:"required"`,
+							Validations: []string{"required"},
+						},
+						{
+							Name:        "Tags",
+							Type:        "[]string",
+							Tag:         `validate:"dive,gte=2"`,
+							Validations: []string{"dive", "gte=2"},
+						},
+					},
+					HasValidateTag:   true,
+					PackageName:      "main",
+					StructuredErrors: true,
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// ValidationError describes a field that failed a validation.
+type ValidationError struct {
+	Field   string
+	Tag     string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return ErrValidation.Error() + ": " + e.Message
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, &ValidationError{Field: "FirstName", Tag: "required", Message: "FirstName required"})
+	}
+
+	for i := range obj.Tags {
+		if len(obj.Tags[i]) < 2 {
+			errs = append(errs, &ValidationError{Field: fmt.Sprintf("Tags[%d]", i), Tag: "gte", Message: fmt.Sprintf("length Tags[%d] must be >= 2", i)})
+		}
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Fail fast",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "FirstName",
+							Type:        "string",
+							Tag:         `validate:"required,gte=2"`,
+							Validations: []string{"required", "gte=2"},
+						},
+						{
+							Name: "Address",
+							Type: "Address",
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+					NestedStructs:  map[string]bool{"User": true, "Address": true},
+					FailFast:       true,
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns the first validation error.
+func UserValidate(obj *User) []error {
+	if obj.FirstName == "" {
+		return []error{fmt.Errorf("%w: FirstName required", ErrValidation)}
+	}
+
+	if len(obj.FirstName) < 2 {
+		return []error{fmt.Errorf("%w: length FirstName must be >= 2", ErrValidation)}
+	}
+
+	if nestedErrs := AddressValidate(&obj.Address); len(nestedErrs) > 0 {
+		return []error{fmt.Errorf("Address: %w", nestedErrs[0])}
+	}
+
+	return nil
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "IsValid helper",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "FirstName",
+							Type:        "string",
+							Tag:         `validate:"required"`,
+							Validations: []string{"required"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+					IsValidHelper:  true,
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
+	}
+
+	return errs
+}
+
+// UserIsValid reports whether a User passes all validations.
+func UserIsValid(obj *User) bool {
+	return len(UserValidate(obj)) == 0
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Custom error message",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "FirstName",
+							Type:        "string",
+							Tag:         `validate:"required,msg=First name is mandatory, really"`,
+							Validations: []string{"required", "msg=First name is mandatory, really"},
+						},
+						{
+							Name:        "LastName",
+							Type:        "string",
+							Tag:         `validate:"required"`,
+							Validations: []string{"required"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: First name is mandatory, really", ErrValidation))
+	}
+
+	if obj.LastName == "" {
+		errs = append(errs, fmt.Errorf("%w: LastName required", ErrValidation))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "JSON names in error messages",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "FirstName",
+							Type:        "string",
+							Tag:         `json:"first_name,omitempty" validate:"required"`,
+							Validations: []string{"required"},
+							JSONName:    "first_name",
+						},
+						{
+							Name:        "LastName",
+							Type:        "string",
+							Tag:         `validate:"required"`,
+							Validations: []string{"required"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: first_name required", ErrValidation))
+	}
+
+	if obj.LastName == "" {
+		errs = append(errs, fmt.Errorf("%w: LastName required", ErrValidation))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Struct without validations",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name:           "User",
+					FieldsInfo:     []FieldInfo{},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want: `package main
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+		{
+			name: "Unknown validation",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "FirstName",
+							Type:        "string",
+							Tag:         `validate:"requird"`,
+							Validations: []string{"requird"},
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "Validator by value",
+			fields: fields{
+				StructInfo: StructInfo{
+					Name: "User",
+					FieldsInfo: []FieldInfo{
+						{
+							Name:        "FirstName",
+							Type:        "string",
+							Tag:         `validate:"required"`,
+							Validations: []string{"required"},
+						},
+						{
+							Name:        "Manager",
+							Type:        "*User",
+							Tag:         `validate:"required"`,
+							Validations: []string{"required"},
+						},
+						{
+							Name: "Address",
+							Type: "Address",
+						},
+					},
+					HasValidateTag: true,
+					PackageName:    "main",
+					NestedStructs:  map[string]bool{"User": true, "Address": true},
+					ByValue:        true,
+				},
+			},
+			want: `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj User) []error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
+	}
+
+	if obj.Manager == nil {
+		errs = append(errs, fmt.Errorf("%w: Manager required", ErrValidation))
+	}
+
+	if obj.Manager != nil {
+		for _, err := range UserValidate(*obj.Manager) {
+			errs = append(errs, fmt.Errorf("Manager: %w", err))
+		}
+	}
+
+	for _, err := range AddressValidate(obj.Address) {
+		errs = append(errs, fmt.Errorf("Address: %w", err))
+	}
+
+	return errs
+}
+`,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fv := tt.fields.StructInfo
+			got, err := fv.GenerateValidator()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FileValidator.Generate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("FileValidator.Generate() = %v, want %v", got, tt.want)
+				dmp := diffmatchpatch.New()
+				diffs := dmp.DiffMain(tt.want, got, false)
+				if len(diffs) > 1 {
+					t.Errorf("FileValidator.Generate() diff = \n%v", dmp.DiffPrettyText(diffs))
+				}
+			}
+		})
+	}
+}
+
+func TestGetFieldTestElements(t *testing.T) {
+	type args struct {
+		fieldName       string
+		displayName     string
+		fieldValidation string
+		fieldType       string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    FieldTestElements
+		wantErr bool
+	}{
+		{
+			name: "Required string",
+			args: args{
+				fieldName:       "myfield1",
+				fieldValidation: "required",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield1",
+				operator:     "==",
+				roperand:     `""`,
+				errorMessage: "myfield1 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Required uint8",
+			args: args{
+				fieldName:       "myfield2",
+				fieldValidation: "required",
+				fieldType:       "uint8",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield2",
+				operator:     "==",
+				roperand:     `0`,
+				errorMessage: "myfield2 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "uint8 >= 0",
+			args: args{
+				fieldName:       "myfield3",
+				fieldValidation: "gte=0",
+				fieldType:       "uint8",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield3",
+				operator:     "<",
+				roperand:     `0`,
+				errorMessage: "myfield3 must be >= 0",
+			},
+			wantErr: false,
+		},
+		{
+			name: "uint8 <= 130",
+			args: args{
+				fieldName:       "myfield4",
+				fieldValidation: "lte=130",
+				fieldType:       "uint8",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield4",
+				operator:     ">",
+				roperand:     `130`,
+				errorMessage: "myfield4 must be <= 130",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String size >= 5",
+			args: args{
+				fieldName:       "myfield5",
+				fieldValidation: "gte=5",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield5)",
+				operator:     "<",
+				roperand:     `5`,
+				errorMessage: "length myfield5 must be >= 5",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String size <= 10",
+			args: args{
+				fieldName:       "myfield6",
+				fieldValidation: "lte=10",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield6)",
+				operator:     ">",
+				roperand:     `10`,
+				errorMessage: "length myfield6 must be <= 10",
+			},
+			wantErr: false,
+		},
+		{
+			name: "uint8 == 42",
+			args: args{
+				fieldName:       "myfield7",
+				fieldValidation: "eq=42",
+				fieldType:       "uint8",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield7",
+				operator:     "!=",
+				roperand:     `42`,
+				errorMessage: "myfield7 must be == 42",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String == admin",
+			args: args{
+				fieldName:       "myfield8",
+				fieldValidation: "eq=admin",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield8",
+				operator:     "!=",
+				roperand:     `"admin"`,
+				errorMessage: "myfield8 must be == admin",
+			},
+			wantErr: false,
+		},
+		{
+			name: "uint8 != 0",
+			args: args{
+				fieldName:       "myfield9",
+				fieldValidation: "ne=0",
+				fieldType:       "uint8",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield9",
+				operator:     "==",
+				roperand:     `0`,
+				errorMessage: "myfield9 must be != 0",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String != root",
+			args: args{
+				fieldName:       "myfield10",
+				fieldValidation: "ne=root",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield10",
+				operator:     "==",
+				roperand:     `"root"`,
+				errorMessage: "myfield10 must be != root",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String size == 6",
+			args: args{
+				fieldName:       "myfield11",
+				fieldValidation: "len=6",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield11)",
+				operator:     "!=",
+				roperand:     `6`,
+				errorMessage: "length myfield11 must be == 6",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Slice size == 3",
+			args: args{
+				fieldName:       "myfield12",
+				fieldValidation: "len=3",
+				fieldType:       "[]string",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield12)",
+				operator:     "!=",
+				roperand:     `3`,
+				errorMessage: "myfield12 must have 3 items",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Len on uint8 is unsupported",
+			args: args{
+				fieldName:       "myfield13",
+				fieldValidation: "len=6",
+				fieldType:       "uint8",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "uint8 > 0",
+			args: args{
+				fieldName:       "myfield14",
+				fieldValidation: "gt=0",
+				fieldType:       "uint8",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield14",
+				operator:     "<=",
+				roperand:     `0`,
+				errorMessage: "myfield14 must be > 0",
+			},
+			wantErr: false,
+		},
+		{
+			name: "uint8 < 100",
+			args: args{
+				fieldName:       "myfield15",
+				fieldValidation: "lt=100",
+				fieldType:       "uint8",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield15",
+				operator:     ">=",
+				roperand:     `100`,
+				errorMessage: "myfield15 must be < 100",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String size > 2",
+			args: args{
+				fieldName:       "myfield16",
+				fieldValidation: "gt=2",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield16)",
+				operator:     "<=",
+				roperand:     `2`,
+				errorMessage: "length myfield16 must be > 2",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String size < 20",
+			args: args{
+				fieldName:       "myfield17",
+				fieldValidation: "lt=20",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield17)",
+				operator:     ">=",
+				roperand:     `20`,
+				errorMessage: "length myfield17 must be < 20",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String one of active inactive pending",
+			args: args{
+				fieldName:       "myfield18",
+				fieldValidation: "oneof=active inactive pending",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `obj.myfield18 != "active" && obj.myfield18 != "inactive" && obj.myfield18 != "pending"`,
+				errorMessage: "myfield18 must be one of [active inactive pending]",
+			},
+			wantErr: false,
+		},
+		{
+			name: "uint8 one of 1 2 3",
+			args: args{
+				fieldName:       "myfield19",
+				fieldValidation: "oneof=1 2 3",
+				fieldType:       "uint8",
+			},
+			want: FieldTestElements{
+				condition:    `obj.myfield19 != 1 && obj.myfield19 != 2 && obj.myfield19 != 3`,
+				errorMessage: "myfield19 must be one of [1 2 3]",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String one of a single value",
+			args: args{
+				fieldName:       "myfield20",
+				fieldValidation: "oneof=admin",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `obj.myfield20 != "admin"`,
+				errorMessage: "myfield20 must be one of [admin]",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String email",
+			args: args{
+				fieldName:       "myfield21",
+				fieldValidation: "email",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "_, err := mail.ParseAddress(obj.myfield21); err != nil",
+				errorMessage: "myfield21 must be a valid email",
+				imports:      []string{"net/mail"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "String url",
+			args: args{
+				fieldName:       "myfield22",
+				fieldValidation: "url",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `u, err := url.ParseRequestURI(obj.myfield22); err != nil || u.Scheme == ""`,
+				errorMessage: "myfield22 must be a valid URL",
+				imports:      []string{"net/url"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "String uuid",
+			args: args{
+				fieldName:       "myfield23",
+				fieldValidation: "uuid",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!uuidRegexp.MatchString(obj.myfield23)",
+				errorMessage: "myfield23 must be a valid UUID",
+				imports:      []string{"regexp"},
+				regexpName:   "uuidRegexp",
+				regexp:       uuidPattern,
+			},
+			wantErr: false,
+		},
+		{
+			name: "String regexp",
+			args: args{
+				fieldName:       "myfield24",
+				fieldValidation: "regexp=^[A-Z]{3}$",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!{{.Regexp}}.MatchString(obj.myfield24)",
+				errorMessage: "myfield24 must match ^[A-Z]{3}$",
+				imports:      []string{"regexp"},
+				regexp:       "^[A-Z]{3}$",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String regexp containing =",
+			args: args{
+				fieldName:       "myfield25",
+				fieldValidation: "regexp=^key=[0-9]+$",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!{{.Regexp}}.MatchString(obj.myfield25)",
+				errorMessage: "myfield25 must match ^key=[0-9]+$",
+				imports:      []string{"regexp"},
+				regexp:       "^key=[0-9]+$",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String invalid regexp",
+			args: args{
+				fieldName:       "myfield93",
+				fieldValidation: "regexp=^[A-Z",
+				fieldType:       "string",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "String oneof without values",
+			args: args{
+				fieldName:       "myfield96",
+				fieldValidation: "oneof=",
+				fieldType:       "string",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "Number eq without value",
+			args: args{
+				fieldName:       "myfield97",
+				fieldValidation: "eq=",
+				fieldType:       "int",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "String alpha",
+			args: args{
+				fieldName:       "myfield26",
+				fieldValidation: "alpha",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!alphaRegexp.MatchString(obj.myfield26)",
+				errorMessage: "myfield26 must contain only letters",
+				imports:      []string{"regexp"},
+				regexpName:   "alphaRegexp",
+				regexp:       alphaPattern,
+			},
+			wantErr: false,
+		},
+		{
+			name: "String alphanum",
+			args: args{
+				fieldName:       "myfield27",
+				fieldValidation: "alphanum",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!alphanumRegexp.MatchString(obj.myfield27)",
+				errorMessage: "myfield27 must be alphanumeric",
+				imports:      []string{"regexp"},
+				regexpName:   "alphanumRegexp",
+				regexp:       alphanumPattern,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Alpha on uint8 is unsupported",
+			args: args{
+				fieldName:       "myfield28",
+				fieldValidation: "alpha",
+				fieldType:       "uint8",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "Alphanum on uint8 is unsupported",
+			args: args{
+				fieldName:       "myfield29",
+				fieldValidation: "alphanum",
+				fieldType:       "uint8",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "String numeric",
+			args: args{
+				fieldName:       "myfield30",
+				fieldValidation: "numeric",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!numericRegexp.MatchString(obj.myfield30)",
+				errorMessage: "myfield30 must be numeric",
+				imports:      []string{"regexp"},
+				regexpName:   "numericRegexp",
+				regexp:       numericPattern,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Numeric on uint8 is unsupported",
+			args: args{
+				fieldName:       "myfield31",
+				fieldValidation: "numeric",
+				fieldType:       "uint8",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "String contains",
+			args: args{
+				fieldName:       "myfield32",
+				fieldValidation: "contains=@",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `!strings.Contains(obj.myfield32, "@")`,
+				errorMessage: "myfield32 must contain '@'",
+				imports:      []string{"strings"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "String contains =",
+			args: args{
+				fieldName:       "myfield33",
+				fieldValidation: "contains=a=b",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `!strings.Contains(obj.myfield33, "a=b")`,
+				errorMessage: "myfield33 must contain 'a=b'",
+				imports:      []string{"strings"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "String starts with",
+			args: args{
+				fieldName:       "myfield34",
+				fieldValidation: "startswith=https://",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `!strings.HasPrefix(obj.myfield34, "https://")`,
+				errorMessage: "myfield34 must start with 'https://'",
+				imports:      []string{"strings"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "String ends with",
+			args: args{
+				fieldName:       "myfield35",
+				fieldValidation: "endswith=.com",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `!strings.HasSuffix(obj.myfield35, ".com")`,
+				errorMessage: "myfield35 must end with '.com'",
+				imports:      []string{"strings"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Starts with on uint8 is unsupported",
+			args: args{
+				fieldName:       "myfield36",
+				fieldValidation: "startswith=1",
+				fieldType:       "uint8",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "String lowercase",
+			args: args{
+				fieldName:       "myfield37",
+				fieldValidation: "lowercase",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield37",
+				operator:     "!=",
+				roperand:     "strings.ToLower(obj.myfield37)",
+				errorMessage: "myfield37 must be lowercase",
+				imports:      []string{"strings"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "String uppercase",
+			args: args{
+				fieldName:       "myfield38",
+				fieldValidation: "uppercase",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield38",
+				operator:     "!=",
+				roperand:     "strings.ToUpper(obj.myfield38)",
+				errorMessage: "myfield38 must be uppercase",
+				imports:      []string{"strings"},
+			},
+			wantErr: false,
+		},
+		{
+			// A required bool must be true, as false is its zero value.
+			name: "Required bool",
+			args: args{
+				fieldName:       "myfield39",
+				fieldValidation: "required",
+				fieldType:       "bool",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield39",
+				operator:     "!=",
+				roperand:     `true`,
+				errorMessage: "myfield39 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Bool == true",
+			args: args{
+				fieldName:       "myfield40",
+				fieldValidation: "eq=true",
+				fieldType:       "bool",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield40",
+				operator:     "!=",
+				roperand:     `true`,
+				errorMessage: "myfield40 must be == true",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Bool == false",
+			args: args{
+				fieldName:       "myfield41",
+				fieldValidation: "eq=false",
+				fieldType:       "bool",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield41",
+				operator:     "!=",
+				roperand:     `false`,
+				errorMessage: "myfield41 must be == false",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Bool == invalid literal",
+			args: args{
+				fieldName:       "myfield42",
+				fieldValidation: "eq=yes",
+				fieldType:       "bool",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "float64 >= 0",
+			args: args{
+				fieldName:       "myfield43",
+				fieldValidation: "gte=0",
+				fieldType:       "float64",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield43",
+				operator:     "<",
+				roperand:     `0`,
+				errorMessage: "myfield43 must be >= 0",
+			},
+			wantErr: false,
+		},
+		{
+			name: "float32 <= 1.5",
+			args: args{
+				fieldName:       "myfield44",
+				fieldValidation: "lte=1.5",
+				fieldType:       "float32",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield44",
+				operator:     ">",
+				roperand:     `1.5`,
+				errorMessage: "myfield44 must be <= 1.5",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Required float64",
+			args: args{
+				fieldName:       "myfield45",
+				fieldValidation: "required",
+				fieldType:       "float64",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield45",
+				operator:     "==",
+				roperand:     `0`,
+				errorMessage: "myfield45 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Required pointer",
+			args: args{
+				fieldName:       "myfield46",
+				fieldValidation: "required",
+				fieldType:       "*string",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield46",
+				operator:     "==",
+				roperand:     `nil`,
+				errorMessage: "myfield46 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Slice min items",
+			args: args{
+				fieldName:       "myfield47",
+				fieldValidation: "min=1",
+				fieldType:       "[]int",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield47)",
+				operator:     "<",
+				roperand:     `1`,
+				errorMessage: "myfield47 must have at least 1 items",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Slice max items",
+			args: args{
+				fieldName:       "myfield48",
+				fieldValidation: "max=10",
+				fieldType:       "[]int",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield48)",
+				operator:     ">",
+				roperand:     `10`,
+				errorMessage: "myfield48 must have at most 10 items",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Map len items",
+			args: args{
+				fieldName:       "myfield49",
+				fieldValidation: "len=2",
+				fieldType:       "map[string]int",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield49)",
+				operator:     "!=",
+				roperand:     `2`,
+				errorMessage: "myfield49 must have 2 items",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String equals field",
+			args: args{
+				fieldName:       "myfield50",
+				fieldValidation: "eqfield=Password",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield50",
+				operator:     "!=",
+				roperand:     "obj.Password",
+				errorMessage: "myfield50 must equal Password",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Number greater than field",
+			args: args{
+				fieldName:       "myfield51",
+				fieldValidation: "gtfield=Min",
+				fieldType:       "int",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield51",
+				operator:     "<=",
+				roperand:     "obj.Min",
+				errorMessage: "myfield51 must be greater than Min",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String less than field",
+			args: args{
+				fieldName:       "myfield52",
+				fieldValidation: "ltfield=Title",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield52)",
+				operator:     ">=",
+				roperand:     "len(obj.Title)",
+				errorMessage: "length myfield52 must be less than length Title",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Required time",
+			args: args{
+				fieldName:       "myfield53",
+				fieldValidation: "required",
+				fieldType:       "time.Time",
+			},
+			want: FieldTestElements{
+				condition:    "obj.myfield53.IsZero()",
+				errorMessage: "myfield53 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Datetime layout with spaces and colons",
+			args: args{
+				fieldName:       "myfield54",
+				fieldValidation: "datetime=2006-01-02 15:04:05",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `_, err := time.Parse("2006-01-02 15:04:05", obj.myfield54); err != nil`,
+				errorMessage: "myfield54 must match format 2006-01-02 15:04:05",
+				imports:      []string{"time"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Excludes a space",
+			args: args{
+				fieldName:       "myfield55",
+				fieldValidation: "excludes= ",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `strings.Contains(obj.myfield55, " ")`,
+				errorMessage: "myfield55 must not contain ' '",
+				imports:      []string{"strings"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Required trimmed string",
+			args: args{
+				fieldName:       "myfield56",
+				fieldValidation: "required_trimmed",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `strings.TrimSpace(obj.myfield56) == ""`,
+				errorMessage: "myfield56 required",
+				imports:      []string{"strings"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "IPv4",
+			args: args{
+				fieldName:       "myfield57",
+				fieldValidation: "ipv4",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "ip := net.ParseIP(obj.myfield57); ip == nil || ip.To4() == nil",
+				errorMessage: "myfield57 must be a valid IPv4 address",
+				imports:      []string{"net"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "IPv6",
+			args: args{
+				fieldName:       "myfield58",
+				fieldValidation: "ipv6",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "ip := net.ParseIP(obj.myfield58); ip == nil || ip.To4() != nil",
+				errorMessage: "myfield58 must be a valid IPv6 address",
+				imports:      []string{"net"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "IP",
+			args: args{
+				fieldName:       "myfield59",
+				fieldValidation: "ip",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "net.ParseIP(obj.myfield59) == nil",
+				errorMessage: "myfield59 must be a valid IP address",
+				imports:      []string{"net"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "MAC address",
+			args: args{
+				fieldName:       "myfield60",
+				fieldValidation: "mac",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "_, err := net.ParseMAC(obj.myfield60); err != nil",
+				errorMessage: "myfield60 must be a valid MAC address",
+				imports:      []string{"net"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Semantic version",
+			args: args{
+				fieldName:       "myfield61",
+				fieldValidation: "semver",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!semverRegexp.MatchString(obj.myfield61)",
+				errorMessage: "myfield61 must be a valid semantic version",
+				imports:      []string{"regexp"},
+				regexpName:   "semverRegexp",
+				regexp:       semverPattern,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Latitude",
+			args: args{
+				fieldName:       "myfield62",
+				fieldValidation: "latitude",
+				fieldType:       "float64",
+			},
+			want: FieldTestElements{
+				condition:    "obj.myfield62 < -90 || obj.myfield62 > 90",
+				errorMessage: "myfield62 must be a valid latitude",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Longitude",
+			args: args{
+				fieldName:       "myfield63",
+				fieldValidation: "longitude",
+				fieldType:       "float32",
+			},
+			want: FieldTestElements{
+				condition:    "obj.myfield63 < -180 || obj.myfield63 > 180",
+				errorMessage: "myfield63 must be a valid longitude",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Required map",
+			args: args{
+				fieldName:       "myfield64",
+				fieldValidation: "required",
+				fieldType:       "map[string]string",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield64)",
+				operator:     "==",
+				roperand:     `0`,
+				errorMessage: "myfield64 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Map with min items",
+			args: args{
+				fieldName:       "myfield65",
+				fieldValidation: "min=1",
+				fieldType:       "map[string]string",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield65)",
+				operator:     "<",
+				roperand:     `1`,
+				errorMessage: "myfield65 must have at least 1 items",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Number greater or equal to a negative bound",
+			args: args{
+				fieldName:       "myfield66",
+				fieldValidation: "gte=-40",
+				fieldType:       "int",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield66",
+				operator:     "<",
+				roperand:     `-40`,
+				errorMessage: "myfield66 must be >= -40",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Number less or equal to a negative bound",
+			args: args{
+				fieldName:       "myfield67",
+				fieldValidation: "lte=-0.5",
+				fieldType:       "float64",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield67",
+				operator:     ">",
+				roperand:     `-0.5`,
+				errorMessage: "myfield67 must be <= -0.5",
+			},
+			wantErr: false,
+		},
+		{
+			name: "String is default",
+			args: args{
+				fieldName:       "myfield68",
+				fieldValidation: "isdefault",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `obj.myfield68 != ""`,
+				errorMessage: "myfield68 must be the default value",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Number is default",
+			args: args{
+				fieldName:       "myfield69",
+				fieldValidation: "isdefault",
+				fieldType:       "int64",
+			},
+			want: FieldTestElements{
+				condition:    "obj.myfield69 != 0",
+				errorMessage: "myfield69 must be the default value",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Slice is default",
+			args: args{
+				fieldName:       "myfield70",
+				fieldValidation: "isdefault",
+				fieldType:       "[]string",
+			},
+			want: FieldTestElements{
+				condition:    "len(obj.myfield70) != 0",
+				errorMessage: "myfield70 must be the default value",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Time is default",
+			args: args{
+				fieldName:       "myfield71",
+				fieldValidation: "isdefault",
+				fieldType:       "time.Time",
+			},
+			want: FieldTestElements{
+				condition:    "!obj.myfield71.IsZero()",
+				errorMessage: "myfield71 must be the default value",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Contains any of special characters",
+			args: args{
+				fieldName:       "myfield72",
+				fieldValidation: `containsany=!@#$%="\`,
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    `!strings.ContainsAny(obj.myfield72, "!@#$%=\"\\")`,
+				errorMessage: `myfield72 must contain at least one of !@#$%%="\`,
+				imports:      []string{"strings"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "E.164 phone number",
+			args: args{
+				fieldName:       "myfield73",
+				fieldValidation: "e164",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!e164Regexp.MatchString(obj.myfield73)",
+				errorMessage: "myfield73 must be a valid E.164 phone number",
+				imports:      []string{"regexp"},
+				regexpName:   "e164Regexp",
+				regexp:       `^\+[1-9]\d{1,14}$`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Hex color",
+			args: args{
+				fieldName:       "myfield74",
+				fieldValidation: "hexcolor",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!hexcolorRegexp.MatchString(obj.myfield74)",
+				errorMessage: "myfield74 must be a valid hex color",
+				imports:      []string{"regexp"},
+				regexpName:   "hexcolorRegexp",
+				regexp:       `^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "JSON string",
+			args: args{
+				fieldName:       "myfield75",
+				fieldValidation: "json",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!json.Valid([]byte(obj.myfield75))",
+				errorMessage: "myfield75 must be valid JSON",
+				imports:      []string{"encoding/json"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "JSON byte slice",
+			args: args{
+				fieldName:       "myfield76",
+				fieldValidation: "json",
+				fieldType:       "[]byte",
+			},
+			want: FieldTestElements{
+				condition:    "!json.Valid(obj.myfield76)",
+				errorMessage: "myfield76 must be valid JSON",
+				imports:      []string{"encoding/json"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "JSON is not supported for other slices",
+			args: args{
+				fieldName:       "myfield77",
+				fieldValidation: "json",
+				fieldType:       "[]string",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "Required byte slice",
+			args: args{
+				fieldName:       "myfield78",
+				fieldValidation: "required",
+				fieldType:       "[]byte",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield78)",
+				operator:     "==",
+				roperand:     `0`,
+				errorMessage: "myfield78 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Required string slice",
+			args: args{
+				fieldName:       "myfield94",
+				fieldValidation: "required",
+				fieldType:       "[]string",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield94)",
+				operator:     "==",
+				roperand:     `0`,
+				errorMessage: "myfield94 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Required struct slice",
+			args: args{
+				fieldName:       "myfield95",
+				fieldValidation: "required",
+				fieldType:       "[]Address",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield95)",
+				operator:     "==",
+				roperand:     `0`,
+				errorMessage: "myfield95 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Min byte slice",
+			args: args{
+				fieldName:       "myfield79",
+				fieldValidation: "min=16",
+				fieldType:       "[]byte",
+			},
+			want: FieldTestElements{
+				loperand:     "len(obj.myfield79)",
+				operator:     "<",
+				roperand:     `16`,
+				errorMessage: "length myfield79 must be >= 16",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Between number",
+			args: args{
+				fieldName:       "myfield80",
+				fieldValidation: "between=5,10",
+				fieldType:       "int",
+			},
+			want: FieldTestElements{
+				condition:    "obj.myfield80 < 5 || obj.myfield80 > 10",
+				errorMessage: "myfield80 must be between 5 and 10",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Between string",
+			args: args{
+				fieldName:       "myfield81",
+				fieldValidation: "between=5,10",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "len(obj.myfield81) < 5 || len(obj.myfield81) > 10",
+				errorMessage: "myfield81 length must be between 5 and 10",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Between without upper bound",
+			args: args{
+				fieldName:       "myfield82",
+				fieldValidation: "between=5",
+				fieldType:       "int",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "Between bool",
+			args: args{
+				fieldName:       "myfield83",
+				fieldValidation: "between=5,10",
+				fieldType:       "bool",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "Number greater than or equal to field",
+			args: args{
+				fieldName:       "myfield84",
+				fieldValidation: "gtefield=Min",
+				fieldType:       "float64",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield84",
+				operator:     "<",
+				roperand:     "obj.Min",
+				errorMessage: "myfield84 must be greater than or equal to Min",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Number less than or equal to field",
+			args: args{
+				fieldName:       "myfield85",
+				fieldValidation: "ltefield=Max",
+				fieldType:       "uint",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield85",
+				operator:     ">",
+				roperand:     "obj.Max",
+				errorMessage: "myfield85 must be less than or equal to Max",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Required interface",
+			args: args{
+				fieldName:       "myfield86",
+				fieldValidation: "required",
+				fieldType:       "interface{}",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield86",
+				operator:     "==",
+				roperand:     `nil`,
+				errorMessage: "myfield86 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Required any",
+			args: args{
+				fieldName:       "myfield87",
+				fieldValidation: "required",
+				fieldType:       "any",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield87",
+				operator:     "==",
+				roperand:     `nil`,
+				errorMessage: "myfield87 required",
+			},
+			wantErr: false,
+		},
+		{
+			name: "ULID",
+			args: args{
+				fieldName:       "myfield88",
+				fieldValidation: "ulid",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!ulidRegexp.MatchString(obj.myfield88)",
+				errorMessage: "myfield88 must be a valid ULID",
+				imports:      []string{"regexp"},
+				regexpName:   "ulidRegexp",
+				regexp:       `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "ULID is not supported for numbers",
+			args: args{
+				fieldName:       "myfield89",
+				fieldValidation: "ulid",
+				fieldType:       "int",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "Slug",
+			args: args{
+				fieldName:       "myfield90",
+				fieldValidation: "slug",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				condition:    "!slugRegexp.MatchString(obj.myfield90)",
+				errorMessage: "myfield90 must be a valid slug",
+				imports:      []string{"regexp"},
+				regexpName:   "slugRegexp",
+				regexp:       `^[a-z0-9]+(?:-[a-z0-9]+)*$`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Slug is not supported for slices",
+			args: args{
+				fieldName:       "myfield91",
+				fieldValidation: "slug",
+				fieldType:       "[]string",
+			},
+			want:    FieldTestElements{},
+			wantErr: true,
+		},
+		{
+			name: "Bool not equal to field",
+			args: args{
+				fieldName:       "myfield92",
+				fieldValidation: "nefield=Enabled",
+				fieldType:       "bool",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.myfield92",
+				operator:     "==",
+				roperand:     "obj.Enabled",
+				errorMessage: "myfield92 must not equal Enabled",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Required string with display name",
+			args: args{
+				fieldName:       "FirstName",
+				displayName:     "first_name",
+				fieldValidation: "required",
+				fieldType:       "string",
+			},
+			want: FieldTestElements{
+				loperand:     "obj.FirstName",
+				operator:     "==",
+				roperand:     `""`,
+				errorMessage: "first_name required",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetFieldTestElements(tt.args.fieldName, tt.args.displayName, tt.args.fieldValidation, tt.args.fieldType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetFieldTestElements() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetFieldTestElements() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructInfoGenerateValidatorImports(t *testing.T) {
+	tests := []struct {
+		name        string
+		fieldsInfo  []FieldInfo
+		imported    []string
+		notImported []string
+	}{
+		{
+			name: "URL field imports net/url",
+			fieldsInfo: []FieldInfo{
+				{Name: "Website", Type: "string", Validations: []string{"url"}},
+			},
+			imported: []string{"fmt", "net/url"},
+		},
+		{
+			name: "No URL field does not import net/url",
+			fieldsInfo: []FieldInfo{
+				{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+				{Name: "Email", Type: "string", Validations: []string{"email"}},
+			},
+			imported:    []string{"fmt", "net/mail"},
+			notImported: []string{"net/url"},
+		},
+		{
+			name: "Datetime imports time",
+			fieldsInfo: []FieldInfo{
+				{Name: "Birthday", Type: "string", Validations: []string{"datetime=2006-01-02"}},
+			},
+			imported: []string{"fmt", "time"},
+		},
+		{
+			name: "IPv4 imports net",
+			fieldsInfo: []FieldInfo{
+				{Name: "Address", Type: "string", Validations: []string{"ipv4"}},
+			},
+			imported: []string{"fmt", "net"},
+		},
+		{
+			name: "MAC imports net",
+			fieldsInfo: []FieldInfo{
+				{Name: "Hardware", Type: "string", Validations: []string{"mac"}},
+			},
+			imported: []string{"fmt", "net"},
+		},
+		{
+			name: "No MAC field does not import net",
+			fieldsInfo: []FieldInfo{
+				{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+			},
+			imported:    []string{"fmt"},
+			notImported: []string{"net"},
+		},
+		{
+			name: "E.164 imports regexp",
+			fieldsInfo: []FieldInfo{
+				{Name: "Phone", Type: "string", Validations: []string{"e164"}},
+			},
+			imported: []string{"fmt", "regexp"},
+		},
+		{
+			name: "No phone field does not import regexp",
+			fieldsInfo: []FieldInfo{
+				{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+			},
+			imported:    []string{"fmt"},
+			notImported: []string{"regexp"},
+		},
+		{
+			name: "JSON string and byte slice import encoding/json",
+			fieldsInfo: []FieldInfo{
+				{Name: "Payload", Type: "string", Validations: []string{"json"}},
+				{Name: "RawPayload", Type: "[]byte", Validations: []string{"json"}},
+			},
+			imported: []string{"encoding/json", "fmt"},
+		},
+		{
+			name: "No JSON field does not import encoding/json",
+			fieldsInfo: []FieldInfo{
+				{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+			},
+			imported:    []string{"fmt"},
+			notImported: []string{"encoding/json"},
+		},
+		{
+			name: "Lowercase and uppercase import strings",
+			fieldsInfo: []FieldInfo{
+				{Name: "Login", Type: "string", Validations: []string{"lowercase"}},
+				{Name: "Code", Type: "string", Validations: []string{"uppercase"}},
+			},
+			imported: []string{"fmt", "strings"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fv := StructInfo{
+				Name:           "User",
+				FieldsInfo:     tt.fieldsInfo,
+				HasValidateTag: true,
+				PackageName:    "main",
+			}
+			got, err := fv.GenerateValidator()
+			if err != nil {
+				t.Fatalf("FileValidator.Generate() error = %v", err)
+			}
+			for _, importPath := range tt.imported {
+				if !strings.Contains(got, `"`+importPath+`"`) {
+					t.Errorf("FileValidator.Generate() missing import %q:\n%s", importPath, got)
+				}
+			}
+			for _, importPath := range tt.notImported {
+				if strings.Contains(got, `"`+importPath+`"`) {
+					t.Errorf("FileValidator.Generate() unexpected import %q:\n%s", importPath, got)
+				}
+			}
+		})
+	}
+}
+
+func TestStructInfoGenerateValidatorSortedImports(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "Login", Type: "string", Validations: []string{"lowercase"}},
+			{Name: "Website", Type: "string", Validations: []string{"url"}},
+			{Name: "Email", Type: "string", Validations: []string{"email"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+	}
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+
+	want := `import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+`
+	if !strings.Contains(got, want) {
+		t.Errorf("FileValidator.Generate() imports not sorted, want %v in:\n%s", want, got)
+	}
+}
+
+func TestGetFieldTestElementsIntegerWidths(t *testing.T) {
+	integerTypes := []string{
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+	}
+
+	for _, fieldType := range integerTypes {
+		t.Run(fieldType, func(t *testing.T) {
+			got, err := GetFieldTestElements("myfield", "", "gte=5", fieldType)
+			if err != nil {
+				t.Fatalf("GetFieldTestElements() error = %v", err)
+			}
+			want := FieldTestElements{
+				loperand:     "obj.myfield",
+				operator:     "<",
+				roperand:     `5`,
+				errorMessage: "myfield must be >= 5",
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("GetFieldTestElements() = %+v, want %+v", got, want)
+			}
+
+			got, err = GetFieldTestElements("myfield", "", "required", fieldType)
+			if err != nil {
+				t.Fatalf("GetFieldTestElements() error = %v", err)
+			}
+			if got.Condition() != "obj.myfield == 0" {
+				t.Errorf("GetFieldTestElements() condition = %s, want obj.myfield == 0", got.Condition())
+			}
+		})
+	}
+}
+
+func TestGetFieldTestElementsMinMaxAliases(t *testing.T) {
+	tests := []struct {
+		alias     string
+		canonical string
+		fieldType string
+	}{
+		{alias: "min=5", canonical: "gte=5", fieldType: "int"},
+		{alias: "max=130", canonical: "lte=130", fieldType: "int"},
+		{alias: "min=1.5", canonical: "gte=1.5", fieldType: "float64"},
+		{alias: "min=3", canonical: "gte=3", fieldType: "string"},
+		{alias: "max=10", canonical: "lte=10", fieldType: "string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alias+","+tt.fieldType, func(t *testing.T) {
+			got, err := GetFieldTestElements("myfield", "", tt.alias, tt.fieldType)
+			if err != nil {
+				t.Fatalf("GetFieldTestElements() error = %v", err)
+			}
+			want, err := GetFieldTestElements("myfield", "", tt.canonical, tt.fieldType)
+			if err != nil {
+				t.Fatalf("GetFieldTestElements() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("GetFieldTestElements() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestStructInfoGenerate(t *testing.T) {
+	tests := []struct {
+		name       string
+		structInfo StructInfo
+		want       string
+	}{
+		{
+			name:       "Sentinel error",
+			structInfo: StructInfo{PackageName: "main"},
+			want: `package main
+
+import (
+	"errors"
+)
+
+var ErrValidation = errors.New("validation error")
+`,
+		},
+		{
+			name:       "Structured errors declare no error type",
+			structInfo: StructInfo{PackageName: "main", StructuredErrors: true},
+			want: `package main
+
+import (
+	"errors"
+)
+
+var ErrValidation = errors.New("validation error")
+`,
+		},
+		{
+			name:       "Custom sentinel error",
+			structInfo: StructInfo{PackageName: "main", ErrSentinel: "ErrInvalid"},
+			want: `package main
+
+import (
+	"errors"
+)
+
+var ErrInvalid = errors.New("validation error")
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.structInfo.Generate()
+			if err != nil {
+				t.Fatalf("StructInfo.Generate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("StructInfo.Generate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterValidation(t *testing.T) {
+	RegisterValidation("plate", func(fieldName, arg, fieldType string) (FieldTestElements, error) {
+		if fieldType != "string" {
+			return FieldTestElements{}, fmt.Errorf("plate on type %s is unsupported", fieldType)
+		}
+
+		return FieldTestElements{
+			condition:    "!isPlate({{.Name}})",
+			errorMessage: "{{.Name}} must be a valid plate",
+		}, nil
+	})
+	defer delete(customValidations, "plate")
+
+	fv := StructInfo{
+		Name: "Car",
+		FieldsInfo: []FieldInfo{
+			{
+				Name:        "Plate",
+				Type:        "string",
+				Tag:         `validate:"plate"`,
+				Validations: []string{"plate"},
+			},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// CarValidate validates a Car and returns all validation errors.
+func CarValidate(obj *Car) []error {
+	var errs []error
+
+	if !isPlate(obj.Plate) {
+		errs = append(errs, fmt.Errorf("%w: Plate must be a valid plate", ErrValidation))
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("FileValidator.Generate() = %v, want %v", got, want)
+	}
+
+	fv.FieldsInfo[0].Type = "int"
+	if _, err := fv.GenerateValidator(); err == nil {
+		t.Errorf("FileValidator.Generate() expected the custom validation error")
+	}
+}
+
+func TestFieldTestElementsFor(t *testing.T) {
+	field := FieldInfo{
+		Name:        "UserName",
+		Type:        "string",
+		Validations: []string{"required", "gte=5", "lte=10"},
+	}
+	want := []FieldTestElements{
+		{loperand: "obj.UserName", operator: "==", roperand: `""`, errorMessage: "UserName required"},
+		{loperand: "len(obj.UserName)", operator: "<", roperand: "5", errorMessage: "length UserName must be >= 5"},
+		{loperand: "len(obj.UserName)", operator: ">", roperand: "10", errorMessage: "length UserName must be <= 10"},
+	}
+
+	got, err := FieldTestElementsFor(field)
+	if err != nil {
+		t.Fatalf("FieldTestElementsFor() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FieldTestElementsFor() = %v, want %v", got, want)
+	}
+
+	field.Validations = []string{"omitempty", "gte=5", "dive", "required", "msg=Invalid user name"}
+	want = []FieldTestElements{
+		{loperand: "len(obj.UserName)", operator: "<", roperand: "5", errorMessage: "Invalid user name"},
+	}
+
+	got, err = FieldTestElementsFor(field)
+	if err != nil {
+		t.Fatalf("FieldTestElementsFor() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FieldTestElementsFor() = %v, want %v", got, want)
+	}
+
+	field.Validations = []string{"required_if=Country BR"}
+	if _, err := FieldTestElementsFor(field); err == nil {
+		t.Errorf("FieldTestElementsFor() expected an error for a conditional validation")
+	}
+}
+
+func TestGetFieldTestElementsErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		fieldValidation string
+		fieldType       string
+		wantErr         string
+	}{
+		{
+			name:            "Unknown validation",
+			fieldValidation: "requird",
+			fieldType:       "string",
+			wantErr:         `unknown validation "requird" for field FirstName`,
+		},
+		{
+			name:            "Unknown validation with argument",
+			fieldValidation: "lenght=5",
+			fieldType:       "string",
+			wantErr:         `unknown validation "lenght" for field FirstName`,
+		},
+		{
+			name:            "Unsupported type",
+			fieldValidation: "email",
+			fieldType:       "int",
+			wantErr:         "unsupported validation email for field FirstName of type int",
+		},
+		{
+			name:            "Is default on a struct",
+			fieldValidation: "isdefault",
+			fieldType:       "Address",
+			wantErr:         "unsupported validation isdefault for field FirstName of type Address",
+		},
+		{
+			name:            "Negative bound on an unsigned type",
+			fieldValidation: "gte=-5",
+			fieldType:       "uint8",
+			wantErr:         "validation gte=-5 for field FirstName has a negative bound, but type uint8 is unsigned",
+		},
+		{
+			name:            "Unique with non comparable elements",
+			fieldValidation: "unique",
+			fieldType:       "[][]string",
+			wantErr:         "validation unique for field FirstName requires comparable elements instead of [][]string",
+		},
+		{
+			name:            "Latitude on an integer",
+			fieldValidation: "latitude",
+			fieldType:       "int",
+			wantErr:         "unsupported validation latitude for field FirstName of type int",
+		},
+		{
+			name:            "Semantic version on a non string",
+			fieldValidation: "semver",
+			fieldType:       "float64",
+			wantErr:         "unsupported validation semver for field FirstName of type float64",
+		},
+		{
+			name:            "IP on a non string",
+			fieldValidation: "ip",
+			fieldType:       "int",
+			wantErr:         "unsupported validation ip for field FirstName of type int",
+		},
+		{
+			name:            "IPv6 on a non string",
+			fieldValidation: "ipv6",
+			fieldType:       "[]byte",
+			wantErr:         "unsupported validation ipv6 for field FirstName of type []byte",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GetFieldTestElements("FirstName", "", tt.fieldValidation, tt.fieldType)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("GetFieldTestElements() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStructInfoGenerateValidatorByValue(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+			{Name: "Nickname", Type: "*string", Validations: []string{"required", "gte=3"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+		IsValidHelper:  true,
+	}
+
+	byPointer, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+
+	fv.ByValue = true
+	byValue, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+
+	want := strings.Replace(byPointer, "(obj *User)", "(obj User)", -1)
+	if byValue != want {
+		t.Errorf("FileValidator.Generate() by value = %v, want %v", byValue, want)
+	}
+}
+
+func TestStructInfoGenerateValidatorAsMethod(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+			{Name: "Address", Type: "Address", Validations: []string{"required"}},
+			{Name: "Manager", Type: "*Manager", Validations: []string{"required"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+		NestedStructs:  map[string]bool{"Address": true, "Manager": true},
+		IsValidHelper:  true,
+		AsMethod:       true,
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// Validate validates a User and returns all validation errors.
+func (obj *User) Validate() []error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
+	}
+
+	for _, err := range obj.Address.Validate() {
+		errs = append(errs, fmt.Errorf("Address: %w", err))
+	}
+
+	if obj.Manager == nil {
+		errs = append(errs, fmt.Errorf("%w: Manager required", ErrValidation))
+	}
+
+	if obj.Manager != nil {
+		for _, err := range obj.Manager.Validate() {
+			errs = append(errs, fmt.Errorf("Manager: %w", err))
+		}
+	}
+
+	return errs
+}
+
+// IsValid reports whether a User passes all validations.
+func (obj *User) IsValid() bool {
+	return len(obj.Validate()) == 0
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestStructInfoGenerateValidatorSkippedField(t *testing.T) {
+	src := `package main
+
+type User struct {
+	FirstName string ` + "`validate:\"required\"`" + `
+	Internal  string ` + "`validate:\"-\"`" + `
+	Address   Address ` + "`validate:\"-\"`" + `
+	LastName  string ` + "`validate:\"required\"`" + `
+}
+`
+
+	structs, err := parseStructs("user.go", src, defaultTagName)
+	if err != nil {
+		t.Fatalf("parseStructs() error = %v", err)
+	}
+
+	fv := structs[0]
+	fv.NestedStructs = map[string]bool{"Address": true}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
+	}
+
+	if obj.LastName == "" {
+		errs = append(errs, fmt.Errorf("%w: LastName required", ErrValidation))
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestFormatCode(t *testing.T) {
+	code := `package main
+func UserValidate(obj *User) []error {
+var errs []error
+
+
+		if obj.FirstName == ""   {
+	errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
+		}
+	return errs
+}
+`
+
+	want := `package main
+
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
+	}
+	return errs
+}
+`
+
+	got, err := formatCode([]byte(code))
+	if err != nil {
+		t.Fatalf("formatCode() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("formatCode() = %v, want %v", got, want)
+	}
+
+	if _, err := formatCode([]byte("package main\nfunc {")); err == nil {
+		t.Errorf("formatCode() expected an error for invalid code")
+	}
+}
+
+func TestStructInfoGenerateValidatorJoinErrors(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+			{Name: "Address", Type: "Address"},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+		NestedStructs:  map[string]bool{"Address": true},
+		IsValidHelper:  true,
+		JoinErrors:     true,
+	}
+
+	want := `package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
+	}
+
+	if err := AddressValidate(&obj.Address); err != nil {
+		errs = append(errs, fmt.Errorf("Address: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// UserIsValid reports whether a User passes all validations.
+func UserIsValid(obj *User) bool {
+	return UserValidate(obj) == nil
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestStructInfoGenerateValidatorValidateInto(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+			{Name: "Address", Type: "Address"},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+		NestedStructs:  map[string]bool{"Address": true},
+		IsValidHelper:  true,
+		ValidateInto:   true,
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User, errs []error) []error {
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
+	}
+
+	for _, err := range AddressValidate(&obj.Address, nil) {
+		errs = append(errs, fmt.Errorf("Address: %w", err))
+	}
+
+	return errs
+}
+
+// UserIsValid reports whether a User passes all validations.
+func UserIsValid(obj *User) bool {
+	return len(UserValidate(obj, nil)) == 0
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+
+	// The errors of the caller cannot be joined into the returned error.
+	fv.JoinErrors = true
+	for _, failFast := range []bool{false, true} {
+		fv.FailFast = failFast
+		if _, err := fv.GenerateValidator(); err == nil {
+			t.Errorf("FileValidator.Generate() expected an error joining the errors of the caller, with FailFast %v", failFast)
+		}
+	}
+}
+
+func TestGetFieldTestElementsRequiredTrimmed(t *testing.T) {
+	untrimmed, err := GetFieldTestElements("Name", "", "required", "string")
+	if err != nil {
+		t.Fatalf("GetFieldTestElements() error = %v", err)
+	}
+	trimmed, err := GetFieldTestElements("Name", "", "required_trimmed", "string")
+	if err != nil {
+		t.Fatalf("GetFieldTestElements() error = %v", err)
+	}
+
+	if got, want := untrimmed.Condition(), `obj.Name == ""`; got != want {
+		t.Errorf("required condition = %s, want %s", got, want)
+	}
+	if got, want := trimmed.Condition(), `strings.TrimSpace(obj.Name) == ""`; got != want {
+		t.Errorf("required_trimmed condition = %s, want %s", got, want)
+	}
+	if trimmed.errorMessage != untrimmed.errorMessage {
+		t.Errorf("required_trimmed message = %s, want %s", trimmed.errorMessage, untrimmed.errorMessage)
+	}
+}
+
+func TestHostnamePatterns(t *testing.T) {
+	hostnameRegexp := regexp.MustCompile(hostnamePattern)
+	fqdnRegexp := regexp.MustCompile(fqdnPattern)
+
+	for _, host := range []string{"localhost", "db-1", "api.example.com"} {
+		if !hostnameRegexp.MatchString(host) {
+			t.Errorf("hostnamePattern does not match %s", host)
+		}
+	}
+	for _, host := range []string{"-db", "db_1", "api..example.com"} {
+		if hostnameRegexp.MatchString(host) {
+			t.Errorf("hostnamePattern matches %s", host)
+		}
+	}
+
+	for _, domain := range []string{"example.com", "api.example.co.uk", "example.com."} {
+		if !fqdnRegexp.MatchString(domain) {
+			t.Errorf("fqdnPattern does not match %s", domain)
+		}
+	}
+	for _, domain := range []string{"localhost", "example.c0m", "example."} {
+		if fqdnRegexp.MatchString(domain) {
+			t.Errorf("fqdnPattern matches %s", domain)
+		}
+	}
+}
+
+func TestColorPatterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   []string
+		invalid []string
+	}{
+		{
+			pattern: rgbPattern,
+			valid:   []string{"rgb(255,0,0)", "rgb( 12 , 200 , 7 )"},
+			invalid: []string{"rgb(256,0,0)", "rgb(1,2)", "rgba(1,2,3,1)"},
+		},
+		{
+			pattern: rgbaPattern,
+			valid:   []string{"rgba(255,0,0,1)", "rgba(0, 0, 0, 0.5)", "rgba(1,2,3,.25)"},
+			invalid: []string{"rgba(255,0,0)", "rgba(0,0,0,2)", "rgb(1,2,3)"},
+		},
+		{
+			pattern: hexcolorPattern,
+			valid:   []string{"#fff", "#1A2b3C"},
+			invalid: []string{"fff", "#ffff", "#12345g"},
+		},
+	}
+
+	for _, tt := range tests {
+		colorRegexp := regexp.MustCompile(tt.pattern)
+		for _, color := range tt.valid {
+			if !colorRegexp.MatchString(color) {
+				t.Errorf("%s does not match %s", tt.pattern, color)
+			}
+		}
+		for _, color := range tt.invalid {
+			if colorRegexp.MatchString(color) {
+				t.Errorf("%s matches %s", tt.pattern, color)
+			}
+		}
+	}
+}
+
+func TestSemverPattern(t *testing.T) {
+	semverRegexp := regexp.MustCompile(semverPattern)
+
+	for _, version := range []string{"1.2.3", "0.0.1-alpha.1", "1.0.0+build.5", "10.20.30-rc.1+sha.abc"} {
+		if !semverRegexp.MatchString(version) {
+			t.Errorf("semverPattern does not match %s", version)
+		}
+	}
+
+	for _, version := range []string{"1.2", "01.2.3", "v1.2.3", "1.2.3-"} {
+		if semverRegexp.MatchString(version) {
+			t.Errorf("semverPattern matches %s", version)
+		}
+	}
+}
+
+func TestStructInfoGenerateValidatorHelpers(t *testing.T) {
+	fv := StructInfo{
+		Name: "Payment",
+		FieldsInfo: []FieldInfo{
+			{Name: "Card", Type: "string", Validations: []string{"credit_card"}},
+			{Name: "BackupCard", Type: "string", Validations: []string{"omitempty", "credit_card"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+	}
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+
+	if count := strings.Count(got, "func luhnValid(s string) bool {"); count != 1 {
+		t.Errorf("FileValidator.Generate() declares luhnValid %d times, want 1:\n%s", count, got)
+	}
+	if !strings.Contains(got, "if !luhnValid(obj.Card) {") {
+		t.Errorf("FileValidator.Generate() missing credit card check:\n%s", got)
+	}
+
+	fv.FieldsInfo = []FieldInfo{{Name: "Name", Type: "string", Validations: []string{"required"}}}
+	got, err = fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if strings.Contains(got, "luhnValid") {
+		t.Errorf("FileValidator.Generate() unexpected luhnValid helper:\n%s", got)
+	}
+}
+
+func TestStructInfoGenerateValidatorUnique(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "Tags", Type: "[]string", Validations: []string{"unique"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// hasDuplicates reports whether some value appears more than once.
+func hasDuplicates[T comparable](values []T) bool {
+	seen := make(map[T]struct{}, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			return true
+		}
+		seen[value] = struct{}{}
+	}
+
+	return false
+}
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if hasDuplicates(obj.Tags) {
+		errs = append(errs, fmt.Errorf("%w: Tags must contain unique values", ErrValidation))
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestStructInfoGenerateValidatorFuseRanges(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "UserName", Type: "string", Validations: []string{"required", "gte=5", "lte=10"}},
+			{Name: "Age", Type: "int", Validations: []string{"lte=130", "gte=0"}},
+			{Name: "Score", Type: "int", Validations: []string{"gte=1"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+		FuseRanges:     true,
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.UserName == "" {
+		errs = append(errs, fmt.Errorf("%w: UserName required", ErrValidation))
+	}
+
+	if len(obj.UserName) < 5 || len(obj.UserName) > 10 {
+		errs = append(errs, fmt.Errorf("%w: UserName length must be between 5 and 10", ErrValidation))
+	}
+
+	if obj.Age < 0 || obj.Age > 130 {
+		errs = append(errs, fmt.Errorf("%w: Age must be between 0 and 130", ErrValidation))
+	}
+
+	if obj.Score < 1 {
+		errs = append(errs, fmt.Errorf("%w: Score must be >= 1", ErrValidation))
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestStructInfoGenerateValidatorBetween(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "UserName", Type: "string", Validations: []string{"between=5, 10"}},
+			{Name: "Nickname", Type: "string", Validations: []string{"between=2,8"}},
+			{Name: "Age", Type: "int", Validations: []string{"between=18,130"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+		CountRunes:     true,
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if utf8.RuneCountInString(obj.UserName) < 5 || utf8.RuneCountInString(obj.UserName) > 10 {
+		errs = append(errs, fmt.Errorf("%w: UserName length must be between 5 and 10", ErrValidation))
+	}
+
+	if utf8.RuneCountInString(obj.Nickname) < 2 || utf8.RuneCountInString(obj.Nickname) > 8 {
+		errs = append(errs, fmt.Errorf("%w: Nickname length must be between 2 and 8", ErrValidation))
+	}
+
+	if obj.Age < 18 || obj.Age > 130 {
+		errs = append(errs, fmt.Errorf("%w: Age must be between 18 and 130", ErrValidation))
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestStructInfoGenerateValidatorNamedTypes(t *testing.T) {
+	src := `package main
+
+type Age uint8
+
+type Slug string
+
+type User struct {
+	Birthday Age  ` + "`validate:\"gte=0,lte=130\"`" + `
+	Handle   Slug ` + "`validate:\"gte=3,lte=20,lowercase\"`" + `
+	Partner  *Age ` + "`validate:\"required,lte=130\"`" + `
+}
+`
+
+	structs, err := parseStructs("user.go", src, defaultTagName)
+	if err != nil {
+		t.Fatalf("parseStructs() error = %v", err)
+	}
+
+	fv, ok := StructInfo{}, false
+	for _, structInfo := range structs {
+		if structInfo.Name == "User" {
+			fv, ok = structInfo, true
+		}
+	}
+	if !ok {
+		t.Fatalf("parseStructs() did not return User: %+v", structs)
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.Birthday < 0 {
+		errs = append(errs, fmt.Errorf("%w: Birthday must be >= 0", ErrValidation))
+	}
+
+	if obj.Birthday > 130 {
+		errs = append(errs, fmt.Errorf("%w: Birthday must be <= 130", ErrValidation))
+	}
+
+	if len(string(obj.Handle)) < 3 {
+		errs = append(errs, fmt.Errorf("%w: length Handle must be >= 3", ErrValidation))
+	}
+
+	if len(string(obj.Handle)) > 20 {
+		errs = append(errs, fmt.Errorf("%w: length Handle must be <= 20", ErrValidation))
+	}
+
+	if string(obj.Handle) != strings.ToLower(string(obj.Handle)) {
+		errs = append(errs, fmt.Errorf("%w: Handle must be lowercase", ErrValidation))
+	}
+
+	if obj.Partner == nil {
+		errs = append(errs, fmt.Errorf("%w: Partner required", ErrValidation))
+	}
+
+	if obj.Partner != nil {
+		if *obj.Partner > 130 {
+			errs = append(errs, fmt.Errorf("%w: Partner must be <= 130", ErrValidation))
+		}
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestStructInfoGenerateValidatorEmbedded(t *testing.T) {
+	src := `package main
+
+type User struct {
+	FirstName string ` + "`validate:\"required\"`" + `
+}
+
+type Admin struct {
+	User
+	Level int ` + "`validate:\"gte=1\"`" + `
+}
+`
+
+	structs, err := parseStructs("admin.go", src, defaultTagName)
+	if err != nil {
+		t.Fatalf("parseStructs() error = %v", err)
+	}
+
+	fv := structs[1]
+	fv.NestedStructs = map[string]bool{"User": true}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// AdminValidate validates a Admin and returns all validation errors.
+func AdminValidate(obj *Admin) []error {
+	var errs []error
+
+	errs = append(errs, UserValidate(&obj.User)...)
+
+	if obj.Level < 1 {
+		errs = append(errs, fmt.Errorf("%w: Level must be >= 1", ErrValidation))
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := GetFieldTestElements(tt.args.fieldName, tt.args.fieldValidation, tt.args.fieldType)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("GetFieldTestElements() error = %v, wantErr %v", err, tt.wantErr)
-				return
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestStructInfoWriteValidator(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+			{Name: "Age", Type: "int", Validations: []string{"gte=0", "lte=130"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+	}
+
+	want, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("StructInfo.GenerateValidator() error = %v", err)
+	}
+
+	var got bytes.Buffer
+	if err := fv.WriteValidator(&got); err != nil {
+		t.Fatalf("StructInfo.WriteValidator() error = %v", err)
+	}
+	if got.String() != want {
+		t.Errorf("StructInfo.WriteValidator() = %v, want %v", got.String(), want)
+	}
+}
+
+func TestStructInfoGenerateValidatorMapDive(t *testing.T) {
+	fv := StructInfo{
+		Name: "Game",
+		FieldsInfo: []FieldInfo{
+			{Name: "Scores", Type: "map[string]int", Validations: []string{"required", "dive", "gte=0"}},
+			{Name: "Levels", Type: "map[int][]string", Validations: []string{"dive", "dive", "required"}},
+			{Name: "Players", Type: "map[string]Player", Validations: []string{"dive"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+		NestedStructs:  map[string]bool{"Player": true},
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// GameValidate validates a Game and returns all validation errors.
+func GameValidate(obj *Game) []error {
+	var errs []error
+
+	if len(obj.Scores) == 0 {
+		errs = append(errs, fmt.Errorf("%w: Scores required", ErrValidation))
+	}
+
+	for k := range obj.Scores {
+		if obj.Scores[k] < 0 {
+			errs = append(errs, fmt.Errorf("%w: Scores[%s] must be >= 0", ErrValidation, k))
+		}
+	}
+
+	for k := range obj.Levels {
+		for j := range obj.Levels[k] {
+			if obj.Levels[k][j] == "" {
+				errs = append(errs, fmt.Errorf("%w: Levels[%v][%d] required", ErrValidation, k, j))
 			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("GetFieldTestElements() = %+v, want %+v", got, tt.want)
+		}
+	}
+
+	for k, value := range obj.Players {
+		for _, err := range PlayerValidate(&value) {
+			errs = append(errs, fmt.Errorf("Players[%s]: %w", k, err))
+		}
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestStructInfoGenerateValidatorMapKeys(t *testing.T) {
+	fv := StructInfo{
+		Name: "Game",
+		FieldsInfo: []FieldInfo{
+			{Name: "Scores", Type: "map[string]int", Validations: []string{"keys", "gte=2", "endkeys", "gte=0"}},
+			{Name: "Bonus", Type: "map[string]int", Validations: []string{"dive", "keys", "required", "endkeys"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// GameValidate validates a Game and returns all validation errors.
+func GameValidate(obj *Game) []error {
+	var errs []error
+
+	for k := range obj.Scores {
+		if len(k) < 2 {
+			errs = append(errs, fmt.Errorf("%w: length Scores[%s] key must be >= 2", ErrValidation, k))
+		}
+
+		if obj.Scores[k] < 0 {
+			errs = append(errs, fmt.Errorf("%w: Scores[%s] must be >= 0", ErrValidation, k))
+		}
+	}
+
+	for k := range obj.Bonus {
+		if k == "" {
+			errs = append(errs, fmt.Errorf("%w: Bonus[%s] key required", ErrValidation, k))
+		}
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+
+	fv.FieldsInfo = []FieldInfo{{Name: "Scores", Type: "map[string]int", Validations: []string{"keys", "gte=2"}}}
+	if _, err := fv.GenerateValidator(); err == nil || err.Error() != "field Scores: keys without endkeys" {
+		t.Errorf("FileValidator.Generate() error = %v, want keys without endkeys", err)
+	}
+}
+
+func TestStructInfoValidate(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+			{Name: "Active", Type: "bool", Validations: []string{"gte=1"}},
+			{Name: "Age", Type: "int", Validations: []string{"email"}},
+			{Name: "Password", Type: "string", Validations: []string{"eqfield=Confirmation"}},
+			{Name: "Ok", Type: "bool", Validations: []string{"gte=1", "email"}},
+			{Name: "Scores", Type: "[]int", Validations: []string{"email", "dive", "contains=a"}},
+			{Name: "Repeat", Type: "string", Validations: []string{"eqfield="}},
+			{Name: "Internal", Type: "bool", Validations: []string{"-"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+	}
+
+	want := []string{
+		"unsupported validation gte=1 for field Active of type bool",
+		"unsupported validation email for field Age of type int",
+		"field Password: validation eqfield=Confirmation references unknown field Confirmation",
+		"unsupported validation gte=1 for field Ok of type bool",
+		"unsupported validation email for field Ok of type bool",
+		"unsupported validation email for field Scores of type []int",
+		"unsupported validation contains=a for field Scores[%d] of type int",
+		"validation eqfield for field Repeat requires an argument",
+	}
+
+	errs := fv.Validate()
+	var got []string
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StructInfo.Validate() = %q, want %q", got, want)
+	}
+
+	if _, err := fv.GenerateValidator(); err == nil || err.Error() != strings.Join(want, "\n") {
+		t.Errorf("StructInfo.GenerateValidator() error = %v, want all the incompatibilities", err)
+	}
+
+	fv.FieldsInfo = fv.FieldsInfo[:1]
+	if errs := fv.Validate(); len(errs) != 0 {
+		t.Errorf("StructInfo.Validate() = %v, want no errors", errs)
+	}
+}
+
+func TestStructInfoGenerateValidatorBuildTags(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+		BuildTags:      []string{"validate", "linux"},
+	}
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+
+	want := "//go:build validate && linux\n// +build validate,linux\n\npackage main\n"
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("FileValidator.Generate() = %v, want prefix %v", got, want)
+	}
+}
+
+func TestStructInfoGenerateValidatorErrSentinel(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+		},
+		HasValidateTag:  true,
+		PackageName:     "main",
+		ErrSentinel:     "ErrInvalidUser",
+		DeclareSentinel: true,
+	}
+
+	want := `package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidUser = errors.New("validation error")
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrInvalidUser))
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+
+	fv.ErrSentinel = "apperrors.ErrInvalid"
+	if _, err := fv.GenerateValidator(); err == nil {
+		t.Errorf("FileValidator.Generate() expected an error declaring a sentinel of another package")
+	}
+
+	fv.DeclareSentinel = false
+	if _, err := fv.GenerateValidator(); err == nil {
+		t.Errorf("FileValidator.Generate() expected an error for a sentinel of another package without its import path")
+	}
+
+	fv.ErrSentinelImport = "generated/errs"
+	if _, err := fv.GenerateValidator(); err == nil {
+		t.Errorf("FileValidator.Generate() expected an error for a sentinel qualified by another package")
+	}
+
+	fv.ErrSentinelImport = "generated/apperrors"
+	got, err = fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if !strings.Contains(got, `"generated/apperrors"`) || !strings.Contains(got, `fmt.Errorf("%w: FirstName required", apperrors.ErrInvalid)`) || strings.Contains(got, "errors.New") {
+		t.Errorf("FileValidator.Generate() = %v, want the apperrors.ErrInvalid sentinel", got)
+	}
+
+	tests, err := fv.GenerateTests()
+	if err != nil {
+		t.Fatalf("StructInfo.GenerateTests() error = %v", err)
+	}
+
+	runTests(t, map[string]string{
+		"apperrors/apperrors.go": "package apperrors\n\nimport \"errors\"\n\nvar ErrInvalid = errors.New(\"invalid\")\n",
+		"user.go":                "package main\n\ntype User struct{ FirstName string }\n",
+		"user_validator.go":      got,
+		"user_validator_test.go": tests,
+	})
+}
+
+func TestStructInfoGenerateValidatorNestedSlice(t *testing.T) {
+	fv := StructInfo{
+		Name: "Customer",
+		FieldsInfo: []FieldInfo{
+			{Name: "Orders", Type: "[]Order", Validations: []string{"min=1"}},
+			{Name: "Addresses", Type: "[]*Address"},
+			{Name: "Tags", Type: "[]string"},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+		NestedStructs:  map[string]bool{"Order": true, "Address": true},
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// CustomerValidate validates a Customer and returns all validation errors.
+func CustomerValidate(obj *Customer) []error {
+	var errs []error
+
+	if len(obj.Orders) < 1 {
+		errs = append(errs, fmt.Errorf("%w: Orders must have at least 1 items", ErrValidation))
+	}
+
+	for i := range obj.Orders {
+		for _, err := range OrderValidate(&obj.Orders[i]) {
+			errs = append(errs, fmt.Errorf("Orders[%d]: %w", i, err))
+		}
+	}
+
+	for i := range obj.Addresses {
+		if obj.Addresses[i] != nil {
+			for _, err := range AddressValidate(obj.Addresses[i]) {
+				errs = append(errs, fmt.Errorf("Addresses[%d]: %w", i, err))
 			}
-		})
+		}
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestStructInfoGenerateValidatorPrefixStructName(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+			{Name: "Tags", Type: "[]string", Validations: []string{"dive", "required"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+	}
+
+	tests := []struct {
+		prefix bool
+		want   []string
+	}{
+		{
+			prefix: false,
+			want: []string{
+				`fmt.Errorf("%w: FirstName required", ErrValidation)`,
+				`fmt.Errorf("%w: Tags[%d] required", ErrValidation, i)`,
+			},
+		},
+		{
+			prefix: true,
+			want: []string{
+				`fmt.Errorf("%w: User.FirstName required", ErrValidation)`,
+				`fmt.Errorf("%w: User.Tags[%d] required", ErrValidation, i)`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		fv.PrefixStructName = tt.prefix
+
+		got, err := fv.GenerateValidator()
+		if err != nil {
+			t.Fatalf("FileValidator.Generate() error = %v", err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("FileValidator.Generate() with PrefixStructName %v = %v, want %v", tt.prefix, got, want)
+			}
+		}
+	}
+}
+
+func TestStructInfoGenerateValidatorIncludeValue(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required", "gte=2"}},
+			{Name: "Age", Type: "int", Validations: []string{"gte=18"}},
+			{Name: "Role", Type: "string", Validations: []string{"ne=root", "msg=Role must not be root"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+		IncludeValue:   true,
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
+	}
+
+	if len(obj.FirstName) < 2 {
+		errs = append(errs, fmt.Errorf("%w: length FirstName must be >= 2 (got %q)", ErrValidation, obj.FirstName))
+	}
+
+	if obj.Age < 18 {
+		errs = append(errs, fmt.Errorf("%w: Age must be >= 18 (got %v)", ErrValidation, obj.Age))
+	}
+
+	if obj.Role == "root" {
+		errs = append(errs, fmt.Errorf("%w: Role must not be root", ErrValidation))
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+}
+
+func TestStructInfoGenerateValidatorNilCheck(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+		NilCheck:       true,
+	}
+
+	want := `package main
+
+import (
+	"fmt"
+)
+
+// UserValidate validates a User and returns all validation errors.
+func UserValidate(obj *User) []error {
+	if obj == nil {
+		return []error{fmt.Errorf("%w: nil User", ErrValidation)}
+	}
+
+	var errs []error
+
+	if obj.FirstName == "" {
+		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
+	}
+
+	return errs
+}
+`
+
+	got, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if got != want {
+		dmp := diffmatchpatch.New()
+		diffs := dmp.DiffMain(want, got, false)
+		t.Errorf("FileValidator.Generate() diff = %v", dmp.DiffPrettyText(diffs))
+	}
+
+	fv.JoinErrors = true
+	got, err = fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if !strings.Contains(got, `return fmt.Errorf("%w: nil User", ErrValidation)`) {
+		t.Errorf("FileValidator.Generate() = %v, want the nil guard returning a single error", got)
+	}
+
+	fv.JoinErrors = false
+	fv.ByValue = true
+	got, err = fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	if strings.Contains(got, "obj == nil") {
+		t.Errorf("FileValidator.Generate() = %v, want no nil guard in by value validators", got)
+	}
+}
+
+func TestStructInfoGenerateValidatorNamedTypeReferences(t *testing.T) {
+	// The named types are declared in another file of the package.
+	namedTypesSrc := `package main
+
+type Email string
+
+type Level int
+
+type Country string
+`
+	src := `package main
+
+type User struct {
+	Email    Email   ` + "`validate:\"required\"`" + `
+	Confirm  Email   ` + "`validate:\"eqfield=Email\"`" + `
+	Backup   string  ` + "`validate:\"nefield=Email\"`" + `
+	Nickname Email   ` + "`validate:\"gtfield=Confirm\"`" + `
+	MinLevel Level
+	MaxLevel int     ` + "`validate:\"gtefield=MinLevel\"`" + `
+	TopLevel Level   ` + "`validate:\"gtfield=MinLevel\"`" + `
+	Country  Country
+	TaxID    string  ` + "`validate:\"required_if=Country BR\"`" + `
+	Phone    Email   ` + "`validate:\"required_without=Email\"`" + `
+	State    string  ` + "`validate:\"excluded_if=Country US\"`" + `
+}
+`
+
+	dir := t.TempDir()
+	for name, code := range map[string]string{"types.go": namedTypesSrc, "user.go": src} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	structs, err := parseFile(filepath.Join(dir, "user.go"), defaultTagName)
+	if err != nil {
+		t.Fatalf("parseFile() error = %v", err)
+	}
+	fv := structs[0]
+
+	validator, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"string(obj.Confirm) != string(obj.Email)",
+		"obj.Backup == string(obj.Email)",
+		"len(string(obj.Nickname)) <= len(string(obj.Confirm))",
+		"obj.MaxLevel < int(obj.MinLevel)",
+		"obj.TopLevel <= obj.MinLevel",
+		`if string(obj.Country) == "BR" {`,
+		`if string(obj.Email) == "" {`,
+	} {
+		if !strings.Contains(validator, want) {
+			t.Errorf("FileValidator.Generate() = %v, want %v", validator, want)
+		}
+	}
+
+	packageDefinition, err := fv.Generate()
+	if err != nil {
+		t.Fatalf("StructInfo.Generate() error = %v", err)
+	}
+
+	if err := typeCheck(map[string]string{
+		"types.go":          namedTypesSrc,
+		"user.go":           src,
+		"validator.go":      packageDefinition,
+		"user_validator.go": validator,
+	}); err != nil {
+		t.Fatalf("generated validator does not compile: %v\n%s", err, validator)
+	}
+}
+
+func TestStructInfoGenerateValidatorBoolFieldReferences(t *testing.T) {
+	src := `package main
+
+type Settings struct {
+	Enabled bool
+	Active  bool ` + "`validate:\"eqfield=Enabled\"`" + `
+	Hidden  bool ` + "`validate:\"nefield=Enabled\"`" + `
+}
+`
+
+	structs, err := parseStructs("settings.go", src, defaultTagName)
+	if err != nil {
+		t.Fatalf("parseStructs() error = %v", err)
+	}
+	fv := structs[0]
+
+	validator, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("FileValidator.Generate() error = %v", err)
+	}
+	for _, want := range []string{"if obj.Active != obj.Enabled {", "if obj.Hidden == obj.Enabled {"} {
+		if !strings.Contains(validator, want) {
+			t.Errorf("FileValidator.Generate() = %v, want %v", validator, want)
+		}
+	}
+
+	packageDefinition, err := fv.Generate()
+	if err != nil {
+		t.Fatalf("StructInfo.Generate() error = %v", err)
+	}
+
+	if err := typeCheck(map[string]string{
+		"settings.go":           src,
+		"validator.go":          packageDefinition,
+		"settings_validator.go": validator,
+	}); err != nil {
+		t.Fatalf("generated validator does not compile: %v\n%s", err, validator)
 	}
 }
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var structTestsTpl = `package {{.PackageName}}
+
+import (
+{{if .IncludeValue}}	"strings"
+{{end}}	"testing"
+{{if .ErrSentinelImport}}
+	"{{.ErrSentinelImport}}"
+{{end}})
+
+func Test{{.Name}}Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     {{.Name}}
+		message string
+		wantErr bool
+	}{
+{{range .Cases}}		{name: {{printf "%q" .Name}}, obj: {{$.Name}}{ {{.Field}}: {{.Value}} }, message: {{printf "%q" .Message}}, wantErr: {{.WantErr}}},
+{{end}}	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := {{.Sentinel}}.Error() + ": " + tt.message
+			gotErr := false
+			for _, err := range {{.Call}} {
+				if err.Error() == message{{if .IncludeValue}} || strings.HasPrefix(err.Error(), message+" (got "){{end}} {
+					gotErr = true
+				}
+			}
+			if gotErr != tt.wantErr {
+				t.Errorf("{{.FuncName "Validate"}}() error %q = %v, want %v", tt.message, gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+`
+
+var structBenchmarkTpl = `package {{.PackageName}}
+
+import (
+	"testing"
+)
+
+func Benchmark{{.Name}}Validate(b *testing.B) {
+	obj := {{.Name}}{
+{{range .Cases}}		{{.Field}}: {{.Value}},
+{{end}}	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		{{.Call}}
+	}
+}
+`
+
+// testCase is a generated test case, setting one field to a value that
+// passes or fails one of its validations.
+type testCase struct {
+	Name    string
+	Field   string
+	Value   string
+	Message string
+	WantErr bool
+}
+
+type testsCode struct {
+	*validatorCode
+	Object string // Variable holding the validated object.
+	Cases  []testCase
+}
+
+// Call returns the call to the validator inside the generated loop.
+func (tc *testsCode) Call() string {
+	argument := tc.Object
+	if !tc.ByValue && !tc.AsMethod {
+		argument = "&" + argument
+	}
+
+	return tc.ValidatorCall(tc.Name, argument)
+}
+
+// GenerateTests generates a table driven test of the struct validator. Each
+// validation with a literal bound gets a passing and a failing case, derived
+// from the same test elements used by the validator.
+func (fv *StructInfo) GenerateTests() (string, error) {
+	if fv.FailFast || fv.JoinErrors {
+		return "", errors.New("tests can only be generated for validators returning all errors")
+	}
+
+	tests := &testsCode{validatorCode: &validatorCode{StructInfo: fv}, Object: "tt.obj"}
+
+	for _, fieldInfo := range fv.FieldsInfo {
+		if fieldInfo.Skipped() {
+			continue
+		}
+
+		validations := fieldInfo.Validations
+		message := ""
+		if last := len(validations) - 1; last >= 0 && strings.HasPrefix(validations[last], "msg=") {
+			message = strings.TrimPrefix(validations[last], "msg=")
+			validations = validations[:last]
+		}
+
+		if len(validations) > 0 && validations[0] == "omitempty" || hasValidation(validations, "dive") {
+			continue
+		}
+
+		displayName := fieldInfo.DisplayName()
+		if fv.PrefixStructName {
+			displayName = fv.Name + "." + displayName
+		}
+
+		for _, fieldValidation := range validations {
+			testElements, err := GetFieldTestElements(fieldInfo.Name, displayName, fieldValidation, fieldInfo.Type)
+			if err != nil {
+				continue
+			}
+
+			passValue, failValue, ok := sampleValues(testElements, "obj."+fieldInfo.Name, fieldInfo.Type)
+			if !ok {
+				continue
+			}
+
+			caseMessage := message
+			if caseMessage == "" {
+				caseMessage = strings.ReplaceAll(testElements.errorMessage, "%%", "%")
+			}
+
+			tests.Cases = append(tests.Cases,
+				testCase{Name: fieldInfo.Name + " " + fieldValidation + " fails", Field: fieldInfo.Name, Value: failValue, Message: caseMessage, WantErr: true},
+				testCase{Name: fieldInfo.Name + " " + fieldValidation + " passes", Field: fieldInfo.Name, Value: passValue, Message: caseMessage, WantErr: false},
+			)
+		}
+	}
+
+	tmpl, err := template.New("StructTests").Parse(structTestsTpl)
+	if err != nil {
+		return "", err
+	}
+
+	code := new(bytes.Buffer)
+	if err := tmpl.Execute(code, tests); err != nil {
+		return "", err
+	}
+
+	return formatCode(code.Bytes())
+}
+
+// GenerateBenchmark generates a benchmark of the struct validator. The
+// benchmarked object sets the fields whose validations can be sampled to
+// values passing all of them, so the success path is measured.
+func (fv *StructInfo) GenerateBenchmark() (string, error) {
+	benchmark := &testsCode{validatorCode: &validatorCode{StructInfo: fv}, Object: "obj"}
+
+	for _, fieldInfo := range fv.FieldsInfo {
+		if fieldInfo.Skipped() {
+			continue
+		}
+
+		validations := fieldInfo.Validations
+		if last := len(validations) - 1; last >= 0 && strings.HasPrefix(validations[last], "msg=") {
+			validations = validations[:last]
+		}
+
+		if len(validations) == 0 || validations[0] == "omitempty" || hasValidation(validations, "dive") {
+			continue
+		}
+
+		if value, ok := passingValue(fieldInfo, validations); ok {
+			benchmark.Cases = append(benchmark.Cases, testCase{Field: fieldInfo.Name, Value: value})
+		}
+	}
+
+	tmpl, err := template.New("StructBenchmark").Parse(structBenchmarkTpl)
+	if err != nil {
+		return "", err
+	}
+
+	code := new(bytes.Buffer)
+	if err := tmpl.Execute(code, benchmark); err != nil {
+		return "", err
+	}
+
+	return formatCode(code.Bytes())
+}
+
+// passingValue returns a value of the field passing all the validations,
+// chosen among the passing samples of each one.
+func passingValue(fieldInfo FieldInfo, validations []string) (string, bool) {
+	operand := "obj." + fieldInfo.Name
+
+	var tests []FieldTestElements
+	var candidates []string
+	for _, fieldValidation := range validations {
+		testElements, err := GetFieldTestElements(fieldInfo.Name, "", fieldValidation, fieldInfo.Type)
+		if err != nil {
+			return "", false
+		}
+
+		passValue, _, ok := sampleValues(testElements, operand, fieldInfo.Type)
+		if !ok {
+			return "", false
+		}
+
+		tests = append(tests, testElements)
+		candidates = append(candidates, passValue)
+	}
+
+	for _, candidate := range candidates {
+		passes := true
+		for _, testElements := range tests {
+			if sampleFails(testElements, operand, fieldInfo.Type, candidate) {
+				passes = false
+				break
+			}
+		}
+
+		if passes {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// sampleFails reports whether the sample value fails the test elements.
+func sampleFails(testElements FieldTestElements, operand, fieldType, value string) bool {
+	if testElements.loperand == "len("+operand+")" {
+		text, _ := strconv.Unquote(value)
+		value = strconv.Itoa(len(text))
+	} else if typeKind(fieldType) != "number" {
+		return (testElements.operator == "==") == (value == testElements.roperand)
+	}
+
+	number, _ := strconv.ParseFloat(value, 64)
+	bound, _ := strconv.ParseFloat(testElements.roperand, 64)
+
+	switch testElements.operator {
+	case "<":
+		return number < bound
+	case "<=":
+		return number <= bound
+	case ">":
+		return number > bound
+	case ">=":
+		return number >= bound
+	case "==":
+		return number == bound
+	}
+
+	return number != bound
+}
+
+// sampleValues returns values of the field that pass and fail the test
+// elements. Only comparisons of the field, or its length, with a literal are
+// supported.
+func sampleValues(testElements FieldTestElements, operand, fieldType string) (string, string, bool) {
+	if testElements.condition != "" {
+		return "", "", false
+	}
+
+	if testElements.loperand == "len("+operand+")" && typeKind(fieldType) == "string" {
+		passLen, failLen, ok := sampleNumbers(testElements.operator, testElements.roperand, false)
+		if !ok {
+			return "", "", false
+		}
+
+		return strconv.Quote(strings.Repeat("a", int(passLen))), strconv.Quote(strings.Repeat("a", int(failLen))), true
+	}
+
+	if testElements.loperand != operand {
+		return "", "", false
+	}
+
+	switch typeKind(fieldType) {
+	case "string":
+		value, err := strconv.Unquote(testElements.roperand)
+		if err != nil {
+			return "", "", false
+		}
+		other := strconv.Quote(value + "a")
+		return samplePair(testElements.operator, testElements.roperand, other)
+	case "bool":
+		// Cross-field validations compare with another field.
+		if testElements.roperand != "true" && testElements.roperand != "false" {
+			return "", "", false
+		}
+		other := strconv.FormatBool(testElements.roperand != "true")
+		return samplePair(testElements.operator, testElements.roperand, other)
+	case "number":
+		passValue, failValue, ok := sampleNumbers(testElements.operator, testElements.roperand, !isUnsigned(fieldType))
+		if !ok {
+			return "", "", false
+		}
+		return formatNumber(passValue), formatNumber(failValue), true
+	case "pointer":
+		if testElements.roperand != "nil" {
+			return "", "", false
+		}
+		return samplePair(testElements.operator, "nil", "new("+strings.TrimPrefix(fieldType, "*")+")")
+	}
+
+	return "", "", false
+}
+
+// samplePair returns the passing and failing values of an equality test
+// between the literal and another value.
+func samplePair(operator, literal, other string) (string, string, bool) {
+	switch operator {
+	case "==":
+		return other, literal, true
+	case "!=":
+		return literal, other, true
+	}
+
+	return "", "", false
+}
+
+// sampleNumbers returns the passing and failing numbers of a comparison with
+// the literal, which must not be negative unless signed is set.
+func sampleNumbers(operator, literal string, signed bool) (float64, float64, bool) {
+	bound, err := strconv.ParseFloat(literal, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	var passValue, failValue float64
+	switch operator {
+	case "<":
+		passValue, failValue = bound, bound-1
+	case "<=":
+		passValue, failValue = bound+1, bound
+	case ">":
+		passValue, failValue = bound, bound+1
+	case ">=":
+		passValue, failValue = bound-1, bound
+	case "==":
+		passValue, failValue = bound+1, bound
+	case "!=":
+		passValue, failValue = bound, bound+1
+	default:
+		return 0, 0, false
+	}
+
+	if !signed && (passValue < 0 || failValue < 0) {
+		return 0, 0, false
+	}
+
+	return passValue, failValue, true
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
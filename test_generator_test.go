@@ -0,0 +1,236 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// typeCheck type checks the files of a package, given their code by name.
+func typeCheck(codes map[string]string) error {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for name, code := range codes {
+		f, err := parser.ParseFile(fset, name, code, 0)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err := conf.Check("main", fset, files, nil)
+	return err
+}
+
+// runTests runs the tests of a package, given its code by name.
+func runTests(t *testing.T, codes map[string]string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("running the generated tests builds them")
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{"go.mod": "module generated\n\ngo 1.22\n"}
+	for name, code := range codes {
+		files[name] = code
+	}
+	for name, code := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(code), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command("go", "test", ".")
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated tests fail: %v\n%s", err, output)
+	}
+}
+
+func TestStructInfoGenerateTests(t *testing.T) {
+	src := `package main
+
+type User struct {
+	FirstName string ` + "`validate:\"required\"`" + `
+	Name      string ` + "`validate:\"required\"`" + `
+	UserName  string ` + "`validate:\"gte=5,lte=10\"`" + `
+	Age       uint8  ` + "`validate:\"gte=0,lte=130\"`" + `
+	Score     float64 ` + "`validate:\"gt=1.5\"`" + `
+	Active    bool   ` + "`validate:\"eq=true\"`" + `
+	Accepted  bool
+	Confirmed bool   ` + "`validate:\"eqfield=Accepted\"`" + `
+	Declined  bool   ` + "`validate:\"nefield=Accepted\"`" + `
+	Role      string ` + "`validate:\"ne=root,msg=Role must not be root\"`" + `
+	Manager   *string ` + "`validate:\"required\"`" + `
+	Internal  string ` + "`validate:\"-\"`" + `
+}
+`
+
+	structs, err := parseStructs("user.go", src, defaultTagName)
+	if err != nil {
+		t.Fatalf("parseStructs() error = %v", err)
+	}
+	fv := structs[0]
+
+	packageDefinition, err := fv.Generate()
+	if err != nil {
+		t.Fatalf("StructInfo.Generate() error = %v", err)
+	}
+	validator, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("StructInfo.GenerateValidator() error = %v", err)
+	}
+	tests, err := fv.GenerateTests()
+	if err != nil {
+		t.Fatalf("StructInfo.GenerateTests() error = %v", err)
+	}
+
+	codes := map[string]string{
+		"user.go":                src,
+		"validator.go":           packageDefinition,
+		"user_validator.go":      validator,
+		"user_validator_test.go": tests,
+	}
+	if err := typeCheck(codes); err != nil {
+		t.Fatalf("generated tests do not compile: %v\n%s", err, tests)
+	}
+
+	// Messages ending like the ones of other fields do not match them.
+	runTests(t, codes)
+
+	for _, field := range []string{"FirstName", "Name", "UserName", "Age", "Score", "Active", "Role", "Manager"} {
+		if !strings.Contains(tests, `name: "`+field+` `) {
+			t.Errorf("StructInfo.GenerateTests() missing case for field %s:\n%s", field, tests)
+		}
+	}
+	if strings.Contains(tests, "Internal") {
+		t.Errorf("StructInfo.GenerateTests() unexpected case for skipped field:\n%s", tests)
+	}
+	if strings.Contains(tests, "Confirmed") || strings.Contains(tests, "Declined") {
+		t.Errorf("StructInfo.GenerateTests() unexpected case for a field compared with another field:\n%s", tests)
+	}
+	if strings.Contains(tests, `"Age gte=0 fails"`) {
+		t.Errorf("StructInfo.GenerateTests() unexpected negative value for unsigned field:\n%s", tests)
+	}
+}
+
+func TestStructInfoGenerateTestsIncludeValue(t *testing.T) {
+	src := `package main
+
+type User struct {
+	UserName string ` + "`validate:\"gte=5\"`" + `
+	Role     string ` + "`validate:\"ne=root,msg=Role must not be root\"`" + `
+	Count    int    ` + "`validate:\"gte=5\"`" + `
+	MaxCount int    ` + "`validate:\"gte=5\"`" + `
+}
+`
+
+	structs, err := parseStructs("user.go", src, defaultTagName)
+	if err != nil {
+		t.Fatalf("parseStructs() error = %v", err)
+	}
+	fv := structs[0]
+	fv.IncludeValue = true
+
+	tests, err := fv.GenerateTests()
+	if err != nil {
+		t.Fatalf("StructInfo.GenerateTests() error = %v", err)
+	}
+
+	want := `err.Error() == message || strings.HasPrefix(err.Error(), message+" (got ")`
+	if !strings.Contains(tests, want) {
+		t.Errorf("StructInfo.GenerateTests() = %v, want %v", tests, want)
+	}
+
+	validator, err := fv.GenerateValidator()
+	if err != nil {
+		t.Fatalf("StructInfo.GenerateValidator() error = %v", err)
+	}
+
+	// The failing case of UserName is reported with its value.
+	message := "length UserName must be >= 5"
+	if !strings.Contains(validator, message+" (got %q)") || !strings.Contains(tests, strconv.Quote(message)) {
+		t.Errorf("StructInfo.GenerateTests() = %v, want a case for %q", tests, message)
+	}
+
+	packageDefinition, err := fv.Generate()
+	if err != nil {
+		t.Fatalf("StructInfo.Generate() error = %v", err)
+	}
+
+	codes := map[string]string{
+		"user.go":                src,
+		"validator.go":           packageDefinition,
+		"user_validator.go":      validator,
+		"user_validator_test.go": tests,
+	}
+	if err := typeCheck(codes); err != nil {
+		t.Fatalf("generated tests do not compile: %v\n%s", err, tests)
+	}
+
+	runTests(t, codes)
+}
+
+func TestStructInfoGenerateTestsFailFast(t *testing.T) {
+	fv := StructInfo{Name: "User", PackageName: "main", FailFast: true}
+	if _, err := fv.GenerateTests(); err == nil {
+		t.Errorf("StructInfo.GenerateTests() expected an error for fail fast validators")
+	}
+}
+
+func TestStructInfoGenerateBenchmark(t *testing.T) {
+	fv := StructInfo{
+		Name: "User",
+		FieldsInfo: []FieldInfo{
+			{Name: "FirstName", Type: "string", Validations: []string{"required"}},
+			{Name: "UserName", Type: "string", Validations: []string{"required", "gte=5", "lte=10"}},
+			{Name: "Age", Type: "uint8", Validations: []string{"gte=18", "lte=130"}},
+			{Name: "Email", Type: "string", Validations: []string{"email"}},
+			{Name: "Nickname", Type: "string"},
+			{Name: "Accepted", Type: "bool"},
+			{Name: "Confirmed", Type: "bool", Validations: []string{"eqfield=Accepted"}},
+		},
+		HasValidateTag: true,
+		PackageName:    "main",
+	}
+
+	want := `package main
+
+import (
+	"testing"
+)
+
+func BenchmarkUserValidate(b *testing.B) {
+	obj := User{
+		FirstName: "a",
+		UserName:  "aaaaa",
+		Age:       18,
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		UserValidate(&obj)
+	}
+}
+`
+
+	got, err := fv.GenerateBenchmark()
+	if err != nil {
+		t.Fatalf("StructInfo.GenerateBenchmark() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("StructInfo.GenerateBenchmark() = %v, want %v", got, want)
+	}
+}
@@ -4,18 +4,19 @@ import (
 	"fmt"
 )
 
+// UserValidate validates a User and returns all validation errors.
 func UserValidate(obj *User) []error {
 	var errs []error
 
-	if !(obj.FirstName != "") {
+	if obj.FirstName == "" {
 		errs = append(errs, fmt.Errorf("%w: FirstName required", ErrValidation))
 	}
 
-	if !(obj.LastName != "") {
+	if obj.LastName == "" {
 		errs = append(errs, fmt.Errorf("%w: LastName required", ErrValidation))
 	}
 
-	if !(obj.Age != 0) {
+	if obj.Age == 0 {
 		errs = append(errs, fmt.Errorf("%w: Age required", ErrValidation))
 	}
 